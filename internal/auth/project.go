@@ -19,6 +19,7 @@ const msgProjectNotFound = "project not found"
 // ProjectInfo identifies a specific project.
 type ProjectInfo struct {
 	ID         string
+	Name       string
 	DomainID   string
 	DomainName string
 }
@@ -50,6 +51,7 @@ func FindProject(ctx context.Context, identityClient *gophercloud.ServiceClient,
 			}
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   p.DomainID,
 				DomainName: dName,
 			}, nil
@@ -87,6 +89,7 @@ func FindProject(ctx context.Context, identityClient *gophercloud.ServiceClient,
 			}
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   p.DomainID,
 				DomainName: dName,
 			}, nil
@@ -116,6 +119,7 @@ func findProjectInCurrentToken(identityClient *gophercloud.ServiceClient, domain
 		if d1.ID != "" {
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   d1.ID,
 				DomainName: d1.Name,
 			}
@@ -123,6 +127,7 @@ func findProjectInCurrentToken(identityClient *gophercloud.ServiceClient, domain
 		if d2.ID != "" {
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   d2.ID,
 				DomainName: d2.Name,
 			}
@@ -132,6 +137,7 @@ func findProjectInCurrentToken(identityClient *gophercloud.ServiceClient, domain
 		if d1.ID != "" && (domainNameOrID == d1.ID || domainNameOrID == d1.Name) {
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   d1.ID,
 				DomainName: d1.Name,
 			}
@@ -139,6 +145,7 @@ func findProjectInCurrentToken(identityClient *gophercloud.ServiceClient, domain
 		if d2.ID != "" && (domainNameOrID == d2.ID || domainNameOrID == d2.Name) {
 			return &ProjectInfo{
 				ID:         p.ID,
+				Name:       p.Name,
 				DomainID:   d2.ID,
 				DomainName: d2.Name,
 			}
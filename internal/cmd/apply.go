@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/projects"
+	"github.com/spf13/cobra"
+
+	"github.com/sapcc/limesctl/v3/internal/auth"
+	"github.com/sapcc/limesctl/v3/internal/core"
+	"github.com/sapcc/limesctl/v3/internal/limesapi"
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+const stateFileHelp = `The state file is a YAML document that lists the desired settings per domain,
+project, service and resource. Domains and projects are identified by name or
+ID. Settings that do not appear in the file are not managed:
+
+  domains:
+    germany:
+      projects:
+        berlin:
+          compute:
+            cores:
+              max_quota: 200           # "none" removes the constraint
+              forbid_autogrowth: false
+              commitments:             # minimum committed amount per AZ
+                - availability_zone: az-one
+                  amount: 100
+                  duration: 1 year     # or e.g. "1y"
+          object-store:
+            capacity:
+              max_quota: 10 TiB        # or "10TiB"
+
+If the existing commitments for a resource and AZ do not cover the amount, a
+new commitment with the given duration is planned for the difference. Existing
+commitments are never deleted.`
+
+// stateFileFlags define the state file for the plan and apply commands.
+type stateFileFlags struct {
+	file string
+}
+
+// AddToCmd adds the stateFileFlags to the cobra.Command.
+func (f *stateFileFlags) AddToCmd(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "path to the state file")
+	cobra.CheckErr(cmd.MarkFlagRequired("file"))
+}
+
+// computePlans reads the state file and compares it with the current state of
+// each project that appears in it.
+func (f stateFileFlags) computePlans(ctx context.Context) ([]core.ProjectPlan, error) {
+	buf, err := os.ReadFile(f.file)
+	if err != nil {
+		return nil, err
+	}
+	state, err := core.ParseDesiredState(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		plans       []core.ProjectPlan
+		failedCount int
+	)
+	reportErr := func(pName string, err error) {
+		fmt.Fprintf(os.Stderr, "ERROR: project %s: %s\n", pName, err.Error())
+		failedCount++
+	}
+	for _, domainNameOrID := range sortedKeys(state.Domains) {
+		projectStates := state.Domains[domainNameOrID].Projects
+		for _, projectNameOrID := range sortedKeys(projectStates) {
+			pName := domainNameOrID + "/" + projectNameOrID
+			pInfo, err := auth.FindProject(ctx, identityClient, domainNameOrID, projectNameOrID)
+			if err != nil {
+				reportErr(pName, err)
+				continue
+			}
+			plan, err := computeProjectPlan(ctx, pInfo, projectStates[projectNameOrID])
+			if err != nil {
+				for _, err := range unjoinErrors(err) {
+					reportErr(pName, err)
+				}
+				continue
+			}
+			plans = append(plans, plan)
+		}
+	}
+
+	if failedCount > 0 {
+		return nil, fmt.Errorf("could not compute plan: %d error(s) occurred", failedCount)
+	}
+	return plans, nil
+}
+
+func computeProjectPlan(ctx context.Context, pInfo *auth.ProjectInfo, desired core.DesiredProjectState) (core.ProjectPlan, error) {
+	var hasCommitmentTargets bool
+	for _, resStates := range desired {
+		for _, resState := range resStates {
+			hasCommitmentTargets = hasCommitmentTargets || len(resState.Commitments) > 0
+		}
+	}
+
+	report, err := projects.Get(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{
+		Services: sortedKeys(desired),
+	}).Extract()
+	if err != nil {
+		return core.ProjectPlan{}, util.WrapError(err, "could not get project report")
+	}
+	var commitments []limesresources.Commitment
+	if hasCommitmentTargets {
+		commitments, err = limesapi.ListCommitments(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+		if err != nil {
+			return core.ProjectPlan{}, util.WrapError(err, "could not get project commitments")
+		}
+	}
+
+	plan, err := core.ComputeProjectPlan(desired, report, commitments)
+	if err != nil {
+		return core.ProjectPlan{}, err
+	}
+	plan.DomainID = pInfo.DomainID
+	plan.DomainName = pInfo.DomainName
+	plan.ProjectID = pInfo.ID
+	plan.ProjectName = pInfo.Name
+
+	// new commitments need to pass the same checks as with 'project create-commitment'
+	var errs []error
+	for _, req := range plan.NewCommitments {
+		resReport, err := findResourceReport(report, req.ServiceType, req.ResourceName)
+		if err == nil {
+			err = validateCommitmentRequest(req, resReport)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return plan, errors.Join(errs...)
+}
+
+// applyProjectPlan performs the changes of a single project plan. It stops at
+// the first error.
+func applyProjectPlan(ctx context.Context, plan core.ProjectPlan) error {
+	if len(plan.MaxQuotaChanges) > 0 {
+		opts := buildSetMaxQuotaOpts(plan.MaxQuotaChanges)
+		err := limesapi.SetMaxQuota(ctx, limesResourcesClient, plan.DomainID, plan.ProjectID, opts).ExtractErr()
+		if err != nil {
+			return util.WrapError(err, "could not set max quota")
+		}
+	}
+	if len(plan.AutogrowthChanges) > 0 {
+		opts := buildSetForbidAutogrowthOpts(plan.AutogrowthChanges)
+		err := limesapi.SetForbidAutogrowth(ctx, limesResourcesClient, plan.DomainID, plan.ProjectID, opts).ExtractErr()
+		if err != nil {
+			return util.WrapError(err, "could not update autogrowth settings")
+		}
+	}
+	for _, req := range plan.NewCommitments {
+		commitment, err := limesapi.CreateCommitment(ctx, limesResourcesClient, plan.DomainID, plan.ProjectID, req).Extract()
+		if err != nil {
+			return util.WrapError(err, fmt.Sprintf("could not create commitment for %s/%s in %s", req.ServiceType, req.ResourceName, req.AvailabilityZone))
+		}
+		fmt.Printf("created commitment %s for %s/%s in %s in project %s\n",
+			commitment.UUID, req.ServiceType, req.ResourceName, req.AvailabilityZone, plan.ProjectName)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map with string-like keys in a stable order.
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// unjoinErrors splits an error created by errors.Join into its parts.
+func unjoinErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Plan.
+
+type planCmd struct {
+	*cobra.Command
+
+	stateFlags stateFileFlags
+}
+
+func newPlanCmd() *planCmd {
+	plan := &planCmd{}
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Compare the settings of projects with a state file",
+		Long: `Compare the max quota, autogrowth and commitment settings of projects with the
+desired state from a state file, and show which changes 'limesctl apply' would
+make. The command exits with status 2 if there are differences.
+
+` + stateFileHelp,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    plan.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	plan.stateFlags.AddToCmd(cmd)
+
+	plan.Command = cmd
+	return plan
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *planCmd) Run(cmd *cobra.Command, _ []string) error {
+	plans, err := p.stateFlags.computePlans(cmd.Context())
+	if err != nil {
+		return err
+	}
+	err = core.WritePlans(os.Stdout, plans)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(plans, core.ProjectPlan.HasChanges) {
+		return exitCodeError{code: 2, err: errors.New("the current state differs from the state file")}
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Apply.
+
+type applyCmd struct {
+	*cobra.Command
+
+	stateFlags  stateFileFlags
+	autoApprove bool
+}
+
+func newApplyCmd() *applyCmd {
+	apply := &applyCmd{}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Change the settings of projects to match a state file",
+		Long: `Change the max quota, autogrowth and commitment settings of projects to match
+the desired state from a state file.
+
+The changes are shown in the same form as with 'limesctl plan', and need to be
+confirmed interactively unless '--auto-approve' is given. If a change fails,
+the remaining changes for the same project are skipped, but other projects are
+still processed.
+
+` + stateFileHelp,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    apply.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	apply.stateFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVar(&apply.autoApprove, "auto-approve", false, "do not ask for confirmation")
+
+	apply.Command = cmd
+	return apply
+}
+
+// Run is called by Cobra when this command is executed.
+func (a *applyCmd) Run(cmd *cobra.Command, _ []string) error {
+	plans, err := a.stateFlags.computePlans(cmd.Context())
+	if err != nil {
+		return err
+	}
+	err = core.WritePlans(os.Stdout, plans)
+	if err != nil {
+		return err
+	}
+
+	plans = slices.DeleteFunc(plans, func(p core.ProjectPlan) bool { return !p.HasChanges() })
+	if len(plans) == 0 {
+		fmt.Println("No changes required.")
+		return nil
+	}
+	if !a.autoApprove && !askForConfirmation(fmt.Sprintf("Apply changes to %d project(s)?", len(plans))) {
+		return errors.New("aborted")
+	}
+
+	var failedCount int
+	for _, plan := range plans {
+		err := applyProjectPlan(cmd.Context(), plan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: project %s/%s: %s\n", plan.DomainName, plan.ProjectName, err.Error())
+			failedCount++
+			continue
+		}
+		fmt.Printf("applied changes to project %s/%s\n", plan.DomainName, plan.ProjectName)
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("could not apply changes to %d project(s)", failedCount)
+	}
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
 	ratesClusters "github.com/sapcc/gophercloud-sapcc/v2/rates/v1/clusters"
 	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/clusters"
+	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/domains"
 	"github.com/spf13/cobra"
 
+	"github.com/sapcc/limesctl/v3/internal/auth"
 	"github.com/sapcc/limesctl/v3/internal/core"
 	"github.com/sapcc/limesctl/v3/internal/util"
 )
@@ -23,6 +25,7 @@ func newClusterCmd() *cobra.Command {
 	doNotSortFlags(cmd)
 	cmd.AddCommand(newClusterShowCmd().Command)
 	cmd.AddCommand(newClusterShowRatesCmd().Command)
+	cmd.AddCommand(newClusterListExpiringCommitmentsCmd().Command)
 	cmd.AddCommand(newMailTemplateCmd())
 	return cmd
 }
@@ -33,8 +36,9 @@ func newClusterCmd() *cobra.Command {
 type clusterShowCmd struct {
 	*cobra.Command
 
-	filterFlags    resourceFilterFlags
-	outputFmtFlags resourceOutputFmtFlags
+	filterFlags        resourceFilterFlags
+	subcapacitiesFlags subdivisionFlags
+	outputFmtFlags     resourceReportOutputFmtFlags
 }
 
 func newClusterShowCmd() *clusterShowCmd {
@@ -51,6 +55,7 @@ func newClusterShowCmd() *clusterShowCmd {
 	// Flags
 	doNotSortFlags(cmd)
 	clusterShow.filterFlags.AddToCmd(cmd)
+	clusterShow.subcapacitiesFlags.AddToCmd(cmd, "subcapacities")
 	clusterShow.outputFmtFlags.AddToCmd(cmd)
 
 	clusterShow.Command = cmd
@@ -63,8 +68,14 @@ func (c *clusterShowCmd) Run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	filters, err := c.subcapacitiesFlags.validate("subcapacities", outputOpts)
+	if err != nil {
+		return err
+	}
+	requestPerAZReportsIfNeeded(outputOpts)
 
 	res := clusters.Get(cmd.Context(), limesResourcesClient, clusters.GetOpts{
+		Detail:    c.subcapacitiesFlags.enabled,
 		Areas:     c.filterFlags.areas,
 		Services:  util.CastStringsTo[limes.ServiceType](c.filterFlags.services),
 		Resources: util.CastStringsTo[limesresources.ResourceName](c.filterFlags.resources),
@@ -73,8 +84,8 @@ func (c *clusterShowCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(res.Err, "could not get cluster report")
 	}
 
-	if c.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesRep, err := res.Extract()
@@ -82,6 +93,14 @@ func (c *clusterShowCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(err, "could not extract cluster report")
 	}
 
+	if c.subcapacitiesFlags.enabled {
+		rep, err := core.NewSubcapacitiesReport(limesRep, filters)
+		if err != nil {
+			return err
+		}
+		return writeReports(outputOpts, rep)
+	}
+
 	return writeReports(outputOpts, core.ClusterReport{ClusterReport: limesRep})
 }
 
@@ -130,8 +149,8 @@ func (c *clusterShowRatesCmd) Run(cmd *cobra.Command, args []string) error {
 		return util.WrapError(res.Err, "could not get cluster report")
 	}
 
-	if c.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesRep, err := res.Extract()
@@ -141,3 +160,63 @@ func (c *clusterShowRatesCmd) Run(cmd *cobra.Command, args []string) error {
 
 	return writeReports(outputOpts, core.ClusterRatesReport{ClusterReport: limesRep})
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// Cluster list expiring commitments.
+
+type clusterListExpiringCommitmentsCmd struct {
+	*cobra.Command
+
+	expiryFlags    expiringCommitmentsFlags
+	outputFmtFlags commitmentOutputFmtFlags
+}
+
+func newClusterListExpiringCommitmentsCmd() *clusterListExpiringCommitmentsCmd {
+	clusterListExpiringCommitments := &clusterListExpiringCommitmentsCmd{}
+	cmd := &cobra.Command{
+		Use:   "list-expiring-commitments",
+		Short: "Display the commitments that expire soon in all projects of all domains",
+		Long: `Display the commitments that expire soon in all projects of all domains.
+
+By default, a summary is shown that aggregates the commitments by expiry month,
+service, resource and availability zone. Use '--detail' to list every
+commitment; combine it with '--names' to see domain and project names.
+With '--format ics', every commitment is exported as iCalendar events instead.
+
+This command requires a cloud-admin token.`,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    clusterListExpiringCommitments.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	clusterListExpiringCommitments.expiryFlags.AddToCmd(cmd)
+	clusterListExpiringCommitments.outputFmtFlags.AddToCmd(cmd)
+
+	clusterListExpiringCommitments.Command = cmd
+	return clusterListExpiringCommitments
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *clusterListExpiringCommitmentsCmd) Run(cmd *cobra.Command, _ []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	domainReps, err := domains.List(cmd.Context(), limesResourcesClient, domains.ListOpts{}).ExtractDomains()
+	if err != nil {
+		return util.WrapError(err, "could not get domain reports")
+	}
+	var projectInfos []auth.ProjectInfo
+	for _, rep := range domainReps {
+		infos, err := listProjectsInDomain(cmd.Context(), rep.UUID, rep.Name)
+		if err != nil {
+			return err
+		}
+		projectInfos = append(projectInfos, infos...)
+	}
+
+	return writeExpiringCommitments(cmd.Context(), projectInfos, c.expiryFlags, outputOpts)
+}
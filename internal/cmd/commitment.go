@@ -0,0 +1,1184 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
+	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/projects"
+	"github.com/spf13/cobra"
+
+	"github.com/sapcc/limesctl/v3/internal/auth"
+	"github.com/sapcc/limesctl/v3/internal/core"
+	"github.com/sapcc/limesctl/v3/internal/limesapi"
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+func newCommitmentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commitment",
+		Short: "Manage existing commitments",
+		Args:  cobra.NoArgs,
+	}
+	// Flags
+	doNotSortFlags(cmd)
+	// Subcommands
+	cmd.AddCommand(newCommitmentStartTransferCmd().Command)
+	cmd.AddCommand(newCommitmentCancelTransferCmd().Command)
+	cmd.AddCommand(newCommitmentAcceptTransferCmd().Command)
+	cmd.AddCommand(newCommitmentListPublicCmd().Command)
+	cmd.AddCommand(newCommitmentRenewCmd().Command)
+	cmd.AddCommand(newCommitmentConversionsCmd().Command)
+	cmd.AddCommand(newCommitmentConvertCmd().Command)
+	cmd.AddCommand(newCommitmentMergeCmd().Command)
+	cmd.AddCommand(newCommitmentUpdateDurationCmd().Command)
+	return cmd
+}
+
+// findCommitment returns the commitment with the given UUID from the given project.
+func findCommitment(ctx context.Context, pInfo *auth.ProjectInfo, uuid string) (*limesresources.Commitment, error) {
+	commitments, err := limesapi.ListCommitments(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+	if err != nil {
+		return nil, util.WrapError(err, "could not get project commitments")
+	}
+	for _, c := range commitments {
+		if c.UUID == uuid {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("commitment %s does not exist in project %s", uuid, pInfo.ID)
+}
+
+// findProjectsInDomain returns all projects of the given domain that are known to Limes.
+func findProjectsInDomain(ctx context.Context, domainNameOrID string) ([]auth.ProjectInfo, error) {
+	domainID, err := auth.FindDomainID(ctx, identityClient, domainNameOrID)
+	if err != nil {
+		return nil, err
+	}
+	domainName, err := auth.FindDomainName(ctx, identityClient, domainID)
+	if err != nil {
+		return nil, err
+	}
+	return listProjectsInDomain(ctx, domainID, domainName)
+}
+
+// listProjectsInDomain is like findProjectsInDomain, but for a domain whose ID
+// and name are already known.
+func listProjectsInDomain(ctx context.Context, domainID, domainName string) ([]auth.ProjectInfo, error) {
+	limesReps, err := projects.List(ctx, limesResourcesClient, domainID, projects.ListOpts{}).ExtractProjects()
+	if err != nil {
+		return nil, util.WrapError(err, "could not get project reports")
+	}
+
+	result := make([]auth.ProjectInfo, 0, len(limesReps))
+	for _, rep := range limesReps {
+		result = append(result, auth.ProjectInfo{
+			ID:         rep.UUID,
+			Name:       rep.Name,
+			DomainID:   domainID,
+			DomainName: domainName,
+		})
+	}
+	slices.SortFunc(result, func(lhs, rhs auth.ProjectInfo) int {
+		return cmp.Compare(lhs.Name, rhs.Name)
+	})
+	return result, nil
+}
+
+// writeCommitment renders a single commitment result in the requested output format.
+func writeCommitment(res limesapi.CommitmentResult, outputOpts *core.OutputOpts, pInfo *auth.ProjectInfo) error {
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
+	}
+
+	commitment, err := res.Extract()
+	if err != nil {
+		return util.WrapError(err, "could not extract commitment")
+	}
+
+	return writeReports(outputOpts, core.CommitmentsReport{
+		Commitments: []limesresources.Commitment{*commitment},
+		DomainID:    pInfo.DomainID,
+		DomainName:  pInfo.DomainName,
+		ProjectID:   pInfo.ID,
+		ProjectName: pInfo.Name,
+	})
+}
+
+// expiringCommitmentsFlags define the parameters of the domain- and
+// cluster-level reports of expiring commitments.
+type expiringCommitmentsFlags struct {
+	within      string
+	filterFlags commitmentFilterFlags
+	detail      bool
+}
+
+// AddToCmd adds the expiringCommitmentsFlags to the cobra.Command.
+func (f *expiringCommitmentsFlags) AddToCmd(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.within, "within", "90d", "show commitments that expire within this time window (e.g. 90d, 12w)")
+	f.filterFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVar(&f.detail, "detail", false, "show every commitment instead of a summary by expiry month, resource and AZ")
+}
+
+// writeExpiringCommitments collects the commitments of the given projects that
+// expire within the time window and renders them as a summary or in detail.
+// Errors for individual projects are reported, but do not abort the report.
+func writeExpiringCommitments(ctx context.Context, projectInfos []auth.ProjectInfo, flags expiringCommitmentsFlags, outputOpts *core.OutputOpts) error {
+	within, err := parseTimeWindow(flags.within)
+	if err != nil {
+		return err
+	}
+	err = flags.filterFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deadline := now.Add(within)
+	var (
+		reps        []core.CommitmentsReport
+		failedCount int
+	)
+	for _, pInfo := range projectInfos {
+		commitments, err := limesapi.ListCommitments(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: could not get commitments of project %s: %s\n", pInfo.Name, err.Error())
+			failedCount++
+			continue
+		}
+		commitments = slices.DeleteFunc(flags.filterFlags.filter(commitments), func(c limesresources.Commitment) bool {
+			return c.ExpiresAt.Before(now) || c.ExpiresAt.After(deadline)
+		})
+		if len(commitments) == 0 {
+			continue
+		}
+		reps = append(reps, core.CommitmentsReport{
+			Commitments: commitments,
+			DomainID:    pInfo.DomainID,
+			DomainName:  pInfo.DomainName,
+			ProjectID:   pInfo.ID,
+			ProjectName: pInfo.Name,
+			Now:         now,
+		})
+	}
+
+	switch {
+	case isStructuredFormat(outputOpts.Fmt):
+		type projectCommitments struct {
+			DomainID    string                      `json:"domain_id"`
+			DomainName  string                      `json:"domain_name"`
+			ProjectID   string                      `json:"project_id"`
+			ProjectName string                      `json:"project_name"`
+			Commitments []limesresources.Commitment `json:"commitments"`
+		}
+		result := make([]projectCommitments, len(reps))
+		for idx, rep := range reps {
+			result[idx] = projectCommitments{rep.DomainID, rep.DomainName, rep.ProjectID, rep.ProjectName, rep.Commitments}
+		}
+		err = writeStructured(outputOpts, map[string]any{"projects": result})
+	case outputOpts.Fmt == core.OutputFormatICS:
+		err = writeCommitmentsAsICS(reps...)
+	case flags.detail:
+		renderers := make([]core.LimesReportRenderer, len(reps))
+		for idx, rep := range reps {
+			renderers[idx] = rep
+		}
+		err = writeReports(outputOpts, renderers...)
+	default:
+		err = writeReports(outputOpts, core.CommitmentExpirySummary{Reports: reps})
+	}
+	if err != nil {
+		return err
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("could not get commitments of %d project(s)", failedCount)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment start transfer.
+
+type commitmentStartTransferCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	amount         string
+	visibility     string
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentStartTransferCmd() *commitmentStartTransferCmd {
+	commitmentStartTransfer := &commitmentStartTransferCmd{}
+	cmd := &cobra.Command{
+		Use:   "start-transfer <uuid>",
+		Short: "Mark a commitment for transfer to another project",
+		Long: `Mark a commitment for transfer to another project and print its transfer token.
+
+If '--amount' is smaller than the amount of the commitment, Limes splits the
+commitment: a new commitment with the requested amount is marked for transfer,
+and the remainder stays with the original commitment.
+
+With '--visibility public', the commitment is offered to all other projects.
+With '--visibility unlisted' (the default), the receiving project needs to know
+the transfer token.
+
+The transfer token is shown in the 'transfer token' column of the output.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentStartTransfer.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentStartTransfer.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&commitmentStartTransfer.amount, "amount", "", `amount to transfer, with unit suffix if applicable (default: the whole commitment)`)
+	cmd.Flags().StringVar(&commitmentStartTransfer.visibility, "visibility", string(limesresources.CommitmentTransferStatusUnlisted), "visibility of the transfer offer: public, unlisted")
+	commitmentStartTransfer.outputFmtFlags.AddToCmd(cmd)
+
+	commitmentStartTransfer.Command = cmd
+	return commitmentStartTransfer
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentStartTransferCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	transferStatus := limesresources.CommitmentTransferStatus(c.visibility)
+	switch transferStatus {
+	case limesresources.CommitmentTransferStatusPublic, limesresources.CommitmentTransferStatusUnlisted:
+	default:
+		return fmt.Errorf("'--visibility' must be one of [%s, %s], got %s",
+			limesresources.CommitmentTransferStatusPublic, limesresources.CommitmentTransferStatusUnlisted, c.visibility)
+	}
+
+	pInfo, err := c.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+	original, err := findCommitment(cmd.Context(), pInfo, args[0])
+	if err != nil {
+		return err
+	}
+
+	amount := original.Amount
+	if c.amount != "" {
+		amount, err = core.ParseValueInUnit(original.Unit, c.amount)
+		if err != nil {
+			return err
+		}
+	}
+	if amount == 0 || amount > original.Amount {
+		return fmt.Errorf("amount must be between 1 and %s", core.FormatHumanizedValue(original.Unit, original.Amount))
+	}
+
+	res := limesapi.StartCommitmentTransfer(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, original.UUID, limesapi.StartTransferOpts{
+		Amount:         amount,
+		TransferStatus: transferStatus,
+	})
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not start commitment transfer")
+	}
+	transferred, err := res.Extract()
+	if err != nil {
+		return util.WrapError(err, "could not extract commitment")
+	}
+
+	if transferred.UUID != original.UUID {
+		fmt.Fprintf(os.Stderr, "The commitment was split: %s were moved into the new commitment %s, which is marked for transfer. The remaining %s stay in commitment %s.\n",
+			core.FormatHumanizedValue(transferred.Unit, transferred.Amount), transferred.UUID,
+			core.FormatHumanizedValue(original.Unit, original.Amount-transferred.Amount), original.UUID)
+	}
+
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
+	}
+	return writeReports(outputOpts, core.CommitmentsReport{
+		Commitments:       []limesresources.Commitment{*transferred},
+		DomainID:          pInfo.DomainID,
+		DomainName:        pInfo.DomainName,
+		ProjectID:         pInfo.ID,
+		ProjectName:       pInfo.Name,
+		ShowTransferToken: true,
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment cancel transfer.
+
+type commitmentCancelTransferCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentCancelTransferCmd() *commitmentCancelTransferCmd {
+	commitmentCancelTransfer := &commitmentCancelTransferCmd{}
+	cmd := &cobra.Command{
+		Use:   "cancel-transfer <uuid>",
+		Short: "Withdraw a commitment from transfer",
+		Long: `Withdraw a commitment from transfer. Its transfer token becomes invalid.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentCancelTransfer.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentCancelTransfer.projectFlags.AddToCmd(cmd)
+	commitmentCancelTransfer.outputFmtFlags.AddToCmd(cmd)
+
+	commitmentCancelTransfer.Command = cmd
+	return commitmentCancelTransfer
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentCancelTransferCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := c.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+	commitment, err := findCommitment(cmd.Context(), pInfo, args[0])
+	if err != nil {
+		return err
+	}
+	if commitment.TransferStatus == limesresources.CommitmentTransferStatusNone {
+		return fmt.Errorf("commitment %s is not marked for transfer", commitment.UUID)
+	}
+
+	res := limesapi.CancelCommitmentTransfer(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, commitment.UUID)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not cancel commitment transfer")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment accept transfer.
+
+type commitmentAcceptTransferCmd struct {
+	*cobra.Command
+
+	projectFlags   projectFlags
+	token          string
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentAcceptTransferCmd() *commitmentAcceptTransferCmd {
+	commitmentAcceptTransfer := &commitmentAcceptTransferCmd{}
+	cmd := &cobra.Command{
+		Use:   "accept-transfer [target project name or ID]",
+		Short: "Move a commitment that is marked for transfer into a project",
+		Long: `Move a commitment that is marked for transfer into the target project.
+
+The target project name/ID is optional by default and limesctl will get the
+project from current scope. However, if '--domain' flag is used then either
+project name or ID is required.
+
+This command requires a project-admin token for the target project.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentAcceptTransfer.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentAcceptTransfer.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&commitmentAcceptTransfer.token, "token", "", "transfer token of the commitment")
+	commitmentAcceptTransfer.outputFmtFlags.AddToCmd(cmd)
+	cobra.CheckErr(cmd.MarkFlagRequired("token"))
+
+	commitmentAcceptTransfer.Command = cmd
+	return commitmentAcceptTransfer
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentAcceptTransferCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := c.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	if c.token == "" {
+		return errors.New("transfer token must not be empty")
+	}
+
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, c.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	commitment, err := limesapi.GetCommitmentByTransferToken(cmd.Context(), limesResourcesClient, c.token).Extract()
+	if err != nil {
+		return util.WrapError(err, "could not find commitment for transfer token")
+	}
+
+	res := limesapi.TransferCommitment(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, commitment.UUID, c.token)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not accept commitment transfer")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment list public.
+
+type commitmentListPublicCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	service        string
+	resource       string
+	az             string
+	amount         string
+	acceptBestFit  bool
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentListPublicCmd() *commitmentListPublicCmd {
+	commitmentListPublic := &commitmentListPublicCmd{}
+	cmd := &cobra.Command{
+		Use:   "list-public",
+		Short: "Display commitments that other projects offer for transfer",
+		Long: `Display commitments that other projects have publicly marked for transfer.
+
+If '--amount' is given, the offers are sorted by how well they fit the
+requested amount: the smallest offers that cover the amount come first,
+followed by the offers that are too small (largest first). With
+'--accept-best-fit', the smallest offer that covers the amount is moved into
+the project from the current scope (or the one given by '--project').
+
+This command requires a project member permissions. Accepting an offer
+requires a project-admin token.`,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentListPublic.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentListPublic.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&commitmentListPublic.service, "service", "", "service type")
+	cmd.Flags().StringVar(&commitmentListPublic.resource, "resource", "", "resource name")
+	cmd.Flags().StringVar(&commitmentListPublic.az, "az", "", "only show offers in this availability zone")
+	cmd.Flags().StringVar(&commitmentListPublic.amount, "amount", "", "requested amount, with unit suffix if applicable")
+	cmd.Flags().BoolVar(&commitmentListPublic.acceptBestFit, "accept-best-fit", false, "accept the smallest offer that covers '--amount'")
+	commitmentListPublic.outputFmtFlags.AddToCmd(cmd)
+	cobra.CheckErr(cmd.MarkFlagRequired("service"))
+	cobra.CheckErr(cmd.MarkFlagRequired("resource"))
+
+	commitmentListPublic.Command = cmd
+	return commitmentListPublic
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentListPublicCmd) Run(cmd *cobra.Command, _ []string) error {
+	if c.acceptBestFit && c.amount == "" {
+		return errors.New("'--accept-best-fit' requires '--amount'")
+	}
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	offers, err := limesapi.ListPublicCommitments(cmd.Context(), limesResourcesClient, limesapi.ListPublicCommitmentsOpts{
+		ServiceType:  limes.ServiceType(c.service),
+		ResourceName: limesresources.ResourceName(c.resource),
+	}).ExtractCommitments()
+	if err != nil {
+		return util.WrapError(err, "could not get public commitments")
+	}
+	offers = slices.DeleteFunc(offers, func(o limesresources.Commitment) bool {
+		return o.TransferStatus != limesresources.CommitmentTransferStatusPublic ||
+			(c.az != "" && string(o.AvailabilityZone) != c.az)
+	})
+
+	if c.amount != "" && len(offers) > 0 {
+		amount, err := core.ParseValueInUnit(offers[0].Unit, c.amount)
+		if err != nil {
+			return err
+		}
+		sortByBestFit(offers, amount)
+
+		if c.acceptBestFit {
+			if offers[0].Amount < amount {
+				return fmt.Errorf("no offer covers the requested amount of %s", core.FormatHumanizedValue(offers[0].Unit, amount))
+			}
+			return c.accept(cmd.Context(), offers[0], outputOpts)
+		}
+	} else if c.acceptBestFit {
+		return errors.New("no offers available")
+	}
+
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, map[string]any{"commitments": offers})
+	}
+
+	return writeReports(outputOpts, core.CommitmentsReport{
+		Commitments:   offers,
+		Now:           time.Now(),
+		PreserveOrder: c.amount != "",
+	})
+}
+
+func (c *commitmentListPublicCmd) accept(ctx context.Context, offer limesresources.Commitment, outputOpts *core.OutputOpts) error {
+	if offer.TransferToken == nil {
+		return fmt.Errorf("no transfer token was reported for commitment %s", offer.UUID)
+	}
+	pInfo, err := c.projectFlags.findProject(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Accepting commitment %s with %s in %s, expiring at %s.\n",
+		offer.UUID, core.FormatHumanizedValue(offer.Unit, offer.Amount), offer.AvailabilityZone,
+		offer.ExpiresAt.Format(time.RFC3339))
+	res := limesapi.TransferCommitment(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID, offer.UUID, *offer.TransferToken)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not accept commitment transfer")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+// sortByBestFit sorts commitments such that the smallest ones that cover the
+// requested amount come first, followed by the ones that are too small
+// (largest first). Ties are broken by the later expiry date.
+func sortByBestFit(commitments []limesresources.Commitment, amount uint64) {
+	slices.SortStableFunc(commitments, func(lhs, rhs limesresources.Commitment) int {
+		lhsCovers := lhs.Amount >= amount
+		rhsCovers := rhs.Amount >= amount
+		switch {
+		case lhsCovers && !rhsCovers:
+			return -1
+		case !lhsCovers && rhsCovers:
+			return 1
+		case lhsCovers:
+			if lhs.Amount != rhs.Amount {
+				return cmp.Compare(lhs.Amount, rhs.Amount)
+			}
+		default:
+			if lhs.Amount != rhs.Amount {
+				return cmp.Compare(rhs.Amount, lhs.Amount)
+			}
+		}
+		return rhs.ExpiresAt.Compare(lhs.ExpiresAt.Time)
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment renew.
+
+type commitmentRenewCmd struct {
+	*cobra.Command
+
+	domainNameOrID  string
+	projectNameOrID string
+	within          string
+	services        []string
+	resources       []string
+	dryRun          bool
+	yes             bool
+}
+
+func newCommitmentRenewCmd() *commitmentRenewCmd {
+	commitmentRenew := &commitmentRenewCmd{}
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Renew commitments that expire soon",
+		Long: `Renew all confirmed commitments that expire within the given time window and
+that have not been renewed yet.
+
+By default, the commitments of the project from the current scope are
+considered. Use '--project' to select a different project, or '--domain'
+without '--project' to consider every project of that domain.
+
+A plan of the commitments that will be renewed is shown and needs to be
+confirmed interactively unless '--yes' is given. With '--dry-run', limesctl
+stops after showing the plan. The command exits with a non-zero status if any
+commitment could not be renewed.
+
+This command requires a project-admin token, or a domain-admin token when
+'--domain' is used without '--project'.`,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentRenew.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	cmd.Flags().StringVarP(&commitmentRenew.domainNameOrID, "domain", "d", "", "name or ID of the domain")
+	cmd.Flags().StringVarP(&commitmentRenew.projectNameOrID, "project", "p", "", "name or ID of the project (default: project from current scope)")
+	cmd.Flags().StringVar(&commitmentRenew.within, "within", "30d", "renew commitments that expire within this time window (e.g. 30d, 2w)")
+	cmd.Flags().StringSliceVar(&commitmentRenew.services, "service", nil, "only renew commitments for these service types (comma separated list)")
+	cmd.Flags().StringSliceVar(&commitmentRenew.resources, "resource", nil, "only renew commitments for these resource names (comma separated list)")
+	cmd.Flags().BoolVar(&commitmentRenew.dryRun, "dry-run", false, "only show which commitments would be renewed")
+	cmd.Flags().BoolVarP(&commitmentRenew.yes, "yes", "y", false, "do not ask for confirmation")
+
+	commitmentRenew.Command = cmd
+	return commitmentRenew
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentRenewCmd) Run(cmd *cobra.Command, _ []string) error {
+	within, err := parseTimeWindow(c.within)
+	if err != nil {
+		return err
+	}
+	filter := commitmentFilterFlags{
+		services:  c.services,
+		resources: c.resources,
+		statuses:  []string{string(liquid.CommitmentStatusConfirmed)},
+	}
+
+	var projectInfos []auth.ProjectInfo
+	if c.domainNameOrID != "" && c.projectNameOrID == "" {
+		projectInfos, err = findProjectsInDomain(cmd.Context(), c.domainNameOrID)
+	} else {
+		var pInfo *auth.ProjectInfo
+		pInfo, err = auth.FindProject(cmd.Context(), identityClient, c.domainNameOrID, c.projectNameOrID)
+		if pInfo != nil {
+			projectInfos = []auth.ProjectInfo{*pInfo}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// plan: find commitments that expire within the window and were not renewed yet
+	now := time.Now()
+	deadline := now.Add(within)
+	var (
+		plan        []core.CommitmentsReport
+		planned     int
+		failedCount int
+	)
+	for _, pInfo := range projectInfos {
+		commitments, err := limesapi.ListCommitments(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: could not get commitments of project %s: %s\n", pInfo.Name, err.Error())
+			failedCount++
+			continue
+		}
+		commitments = slices.DeleteFunc(filter.filter(commitments), func(c limesresources.Commitment) bool {
+			return c.WasRenewed || c.ExpiresAt.After(deadline)
+		})
+		if len(commitments) == 0 {
+			continue
+		}
+		plan = append(plan, core.CommitmentsReport{
+			Commitments: commitments,
+			DomainID:    pInfo.DomainID,
+			DomainName:  pInfo.DomainName,
+			ProjectID:   pInfo.ID,
+			ProjectName: pInfo.Name,
+			Now:         now,
+		})
+		planned += len(commitments)
+	}
+
+	if planned == 0 {
+		fmt.Printf("No commitments expire until %s.\n", deadline.UTC().Format(time.RFC3339))
+		if failedCount > 0 {
+			return fmt.Errorf("could not get commitments of %d project(s)", failedCount)
+		}
+		return nil
+	}
+	fmt.Println("The following commitments will be renewed:")
+	renderers := make([]core.LimesReportRenderer, len(plan))
+	for idx, rep := range plan {
+		renderers[idx] = rep
+	}
+	err = writeReports(&core.OutputOpts{Fmt: core.OutputFormatTable, CSVRecFmt: core.CSVRecordFormatNames, Humanize: true}, renderers...)
+	if err != nil {
+		return err
+	}
+	if c.dryRun {
+		return nil
+	}
+	if !c.yes && !askForConfirmation(fmt.Sprintf("Renew %d commitment(s)?", planned)) {
+		return errors.New("aborted")
+	}
+
+	// apply
+	for _, rep := range plan {
+		for _, commitment := range rep.Commitments {
+			renewed, err := limesapi.RenewCommitment(cmd.Context(), limesResourcesClient, rep.DomainID, rep.ProjectID, commitment.UUID).Extract()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "ERROR: could not renew commitment %s in project %s: %s\n", commitment.UUID, rep.ProjectName, err.Error())
+				failedCount++
+				continue
+			}
+			fmt.Printf("renewed commitment %s in project %s: new commitment %s will be confirmed at %s\n",
+				commitment.UUID, rep.ProjectName, renewed.UUID, timestampOrNever(renewed.ConfirmBy))
+		}
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("%d error(s) occurred while renewing commitments", failedCount)
+	}
+	return nil
+}
+
+// timestampOrNever renders an optional timestamp for use in messages.
+func timestampOrNever(t *limes.UnixEncodedTime) string {
+	if t == nil {
+		return "once possible"
+	}
+	return t.Format(time.RFC3339)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment conversions.
+
+type commitmentConversionsCmd struct {
+	*cobra.Command
+
+	service        string
+	resource       string
+	outputFmtFlags commonOutputFmtFlags
+}
+
+func newCommitmentConversionsCmd() *commitmentConversionsCmd {
+	commitmentConversions := &commitmentConversionsCmd{}
+	cmd := &cobra.Command{
+		Use:   "conversions",
+		Short: "Display the resources that commitments for a resource can be converted into",
+		Long: `Display the resources that commitments for a resource can be converted into.
+
+The exchange ratio is given as a pair of amounts: a conversion with "from
+amount" = 2 and "to amount" = 3 turns 2 units of the source resource into
+3 units of the target resource.`,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentConversions.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	cmd.Flags().StringVar(&commitmentConversions.service, "service", "", "service type")
+	cmd.Flags().StringVar(&commitmentConversions.resource, "resource", "", "resource name")
+	cmd.Flags().VarP(&commitmentConversions.outputFmtFlags.format, "format", "f", "output format: table (default), json, csv")
+	cobra.CheckErr(cmd.MarkFlagRequired("service"))
+	cobra.CheckErr(cmd.MarkFlagRequired("resource"))
+
+	commitmentConversions.Command = cmd
+	return commitmentConversions
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentConversionsCmd) Run(cmd *cobra.Command, _ []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	srvType := limes.ServiceType(c.service)
+	resName := limesresources.ResourceName(c.resource)
+	res := limesapi.ListCommitmentConversions(cmd.Context(), limesResourcesClient, srvType, resName)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not get commitment conversions")
+	}
+
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
+	}
+
+	rules, err := res.ExtractConversions()
+	if err != nil {
+		return util.WrapError(err, "could not extract commitment conversions")
+	}
+
+	return writeReports(outputOpts, core.CommitmentConversionsReport{
+		Rules:        rules,
+		ServiceType:  srvType,
+		ResourceName: resName,
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment convert.
+
+type commitmentConvertCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	targetResource string
+	amount         string
+	dryRun         bool
+	yes            bool
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentConvertCmd() *commitmentConvertCmd {
+	commitmentConvert := &commitmentConvertCmd{}
+	cmd := &cobra.Command{
+		Use:   "convert <uuid>",
+		Short: "Convert a commitment into a commitment for a different resource",
+		Long: `Convert (a part of) a commitment into a commitment for a different resource.
+
+The amount must be a multiple of the "from amount" of the respective
+conversion rule (see 'limesctl commitment conversions'). If the amount is
+smaller than the commitment, the leftover stays with the original commitment.
+The target resource can be given as "resource" or "service/resource"; the
+latter is required if the resource name is ambiguous.
+
+A preview of the conversion is shown and needs to be confirmed interactively
+unless '--yes' is given. With '--dry-run', limesctl stops after the preview.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentConvert.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentConvert.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&commitmentConvert.targetResource, "target-resource", "", "resource to convert into")
+	cmd.Flags().StringVar(&commitmentConvert.amount, "amount", "", "amount to convert, with unit suffix if applicable (default: the whole commitment)")
+	cmd.Flags().BoolVar(&commitmentConvert.dryRun, "dry-run", false, "only show the preview of the conversion")
+	cmd.Flags().BoolVarP(&commitmentConvert.yes, "yes", "y", false, "do not ask for confirmation")
+	commitmentConvert.outputFmtFlags.AddToCmd(cmd)
+	cobra.CheckErr(cmd.MarkFlagRequired("target-resource"))
+
+	commitmentConvert.Command = cmd
+	return commitmentConvert
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentConvertCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := c.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+	commitment, err := findCommitment(cmd.Context(), pInfo, args[0])
+	if err != nil {
+		return err
+	}
+
+	rules, err := limesapi.ListCommitmentConversions(cmd.Context(), limesResourcesClient, commitment.ServiceType, commitment.ResourceName).ExtractConversions()
+	if err != nil {
+		return util.WrapError(err, "could not get commitment conversions")
+	}
+	rule, err := findConversionRule(rules, c.targetResource)
+	if err != nil {
+		return fmt.Errorf("cannot convert %s/%s: %w", commitment.ServiceType, commitment.ResourceName, err)
+	}
+
+	amount := commitment.Amount
+	if c.amount != "" {
+		amount, err = core.ParseValueInUnit(commitment.Unit, c.amount)
+		if err != nil {
+			return err
+		}
+	}
+	if amount == 0 || amount > commitment.Amount {
+		return fmt.Errorf("amount must be between 1 and %s", core.FormatHumanizedValue(commitment.Unit, commitment.Amount))
+	}
+	if amount%rule.FromAmount != 0 {
+		lower := amount - amount%rule.FromAmount
+		msg := fmt.Sprintf("amount must be a multiple of %d", rule.FromAmount)
+		if lower > 0 {
+			msg += fmt.Sprintf(", e.g. %d", lower)
+		}
+		return errors.New(msg)
+	}
+
+	opts := limesapi.ConvertOpts{
+		TargetService:  rule.TargetService,
+		TargetResource: rule.TargetResource,
+		SourceAmount:   amount,
+		TargetAmount:   amount / rule.FromAmount * rule.ToAmount,
+	}
+	// the conversion rule does not contain the unit of the target resource
+	targetReport, err := getProjectResourceReport(cmd.Context(), pInfo, opts.TargetService, opts.TargetResource)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Converting %s of %s/%s into %s of %s/%s (ratio %d:%d).\n",
+		core.FormatHumanizedValue(commitment.Unit, amount), commitment.ServiceType, commitment.ResourceName,
+		core.FormatHumanizedValue(targetReport.Unit, opts.TargetAmount), opts.TargetService, opts.TargetResource, rule.FromAmount, rule.ToAmount)
+	if leftover := commitment.Amount - amount; leftover > 0 {
+		fmt.Fprintf(os.Stderr, "The leftover of %s stays in commitment %s.\n", core.FormatHumanizedValue(commitment.Unit, leftover), commitment.UUID)
+	}
+	if c.dryRun {
+		return nil
+	}
+	if !c.yes && !askForConfirmation("Convert commitment?") {
+		return errors.New("aborted")
+	}
+
+	res := limesapi.ConvertCommitment(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, commitment.UUID, opts)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not convert commitment")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+// findConversionRule finds the conversion rule for the given target, which is
+// either a resource name or a "service/resource" pair.
+func findConversionRule(rules []limesresources.CommitmentConversionRule, target string) (limesresources.CommitmentConversionRule, error) {
+	targetService, targetResource, hasService := strings.Cut(target, "/")
+	if !hasService {
+		targetService, targetResource = "", target
+	}
+
+	var matches []limesresources.CommitmentConversionRule
+	for _, rule := range rules {
+		if string(rule.TargetResource) == targetResource && (!hasService || string(rule.TargetService) == targetService) {
+			matches = append(matches, rule)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return limesresources.CommitmentConversionRule{}, fmt.Errorf("no conversion into %q is possible", target)
+	case 1:
+		if matches[0].FromAmount == 0 {
+			return limesresources.CommitmentConversionRule{}, fmt.Errorf("invalid conversion rule for %q", target)
+		}
+		return matches[0], nil
+	default:
+		return limesresources.CommitmentConversionRule{}, fmt.Errorf("resource name %q is ambiguous, use the format \"service/resource\"", target)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment merge.
+
+type commitmentMergeCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentMergeCmd() *commitmentMergeCmd {
+	commitmentMerge := &commitmentMergeCmd{}
+	cmd := &cobra.Command{
+		Use:   "merge <uuid> <uuid>...",
+		Short: "Merge several commitments into one",
+		Long: `Merge several commitments into one.
+
+All commitments must belong to the same project, service, resource and
+availability zone, and must be active (i.e. confirmed and not marked for
+transfer). The merged commitment expires at the latest expiry date of the
+original commitments.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.MinimumNArgs(2),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentMerge.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentMerge.projectFlags.AddToCmd(cmd)
+	commitmentMerge.outputFmtFlags.AddToCmd(cmd)
+
+	commitmentMerge.Command = cmd
+	return commitmentMerge
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentMergeCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+	for idx, uuid := range args {
+		if slices.Contains(args[:idx], uuid) {
+			return fmt.Errorf("commitment %s is given more than once", uuid)
+		}
+	}
+
+	pInfo, err := c.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+	commitments, err := limesapi.ListCommitments(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+	if err != nil {
+		return util.WrapError(err, "could not get project commitments")
+	}
+
+	var first *limesresources.Commitment
+	for _, uuid := range args {
+		idx := slices.IndexFunc(commitments, func(c limesresources.Commitment) bool { return c.UUID == uuid })
+		if idx < 0 {
+			return fmt.Errorf("commitment %s does not exist in project %s", uuid, pInfo.ID)
+		}
+		commitment := commitments[idx]
+		if commitment.Status != liquid.CommitmentStatusConfirmed {
+			return fmt.Errorf("commitment %s cannot be merged because it is %s", uuid, commitment.Status)
+		}
+		if commitment.TransferStatus != limesresources.CommitmentTransferStatusNone {
+			return fmt.Errorf("commitment %s cannot be merged because it is marked for transfer", uuid)
+		}
+		if first == nil {
+			first = &commitment
+			continue
+		}
+		if commitment.ServiceType != first.ServiceType || commitment.ResourceName != first.ResourceName ||
+			commitment.AvailabilityZone != first.AvailabilityZone {
+			return fmt.Errorf("commitment %s (%s/%s in %s) does not match commitment %s (%s/%s in %s)",
+				uuid, commitment.ServiceType, commitment.ResourceName, commitment.AvailabilityZone,
+				first.UUID, first.ServiceType, first.ResourceName, first.AvailabilityZone)
+		}
+	}
+
+	res := limesapi.MergeCommitments(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, args)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not merge commitments")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Commitment update duration.
+
+type commitmentUpdateDurationCmd struct {
+	*cobra.Command
+
+	projectFlags   projectScopeFlags
+	duration       string
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newCommitmentUpdateDurationCmd() *commitmentUpdateDurationCmd {
+	commitmentUpdateDuration := &commitmentUpdateDurationCmd{}
+	cmd := &cobra.Command{
+		Use:   "update-duration <uuid>",
+		Short: "Extend the duration of a commitment",
+		Long: `Extend the duration of a commitment.
+
+The new duration must be longer than the current one, and must be one of the
+durations that the resource accepts for new commitments.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    commitmentUpdateDuration.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	commitmentUpdateDuration.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&commitmentUpdateDuration.duration, "duration", "", "new duration, e.g. \"3 years\" or \"3y\"")
+	commitmentUpdateDuration.outputFmtFlags.AddToCmd(cmd)
+	cobra.CheckErr(cmd.MarkFlagRequired("duration"))
+
+	commitmentUpdateDuration.Command = cmd
+	return commitmentUpdateDuration
+}
+
+// Run is called by Cobra when this command is executed.
+func (c *commitmentUpdateDurationCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := c.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+	duration, err := core.ParseCommitmentDuration(c.duration)
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := c.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+	commitment, err := findCommitment(cmd.Context(), pInfo, args[0])
+	if err != nil {
+		return err
+	}
+	resReport, err := getProjectResourceReport(cmd.Context(), pInfo, commitment.ServiceType, commitment.ResourceName)
+	if err != nil {
+		return err
+	}
+	if err := validateDurationUpdate(*commitment, resReport, duration); err != nil {
+		return err
+	}
+
+	res := limesapi.UpdateCommitmentDuration(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, commitment.UUID, duration)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not update commitment duration")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+// validateDurationUpdate checks that the new duration is acceptable for the
+// commitment's resource and longer than the current duration.
+func validateDurationUpdate(commitment limesresources.Commitment, resReport *limesresources.ProjectResourceReport, duration limesresources.CommitmentDuration) error {
+	fullResourceName := fmt.Sprintf("%s/%s", commitment.ServiceType, commitment.ResourceName)
+	cfg := resReport.CommitmentConfig
+	if cfg == nil {
+		return fmt.Errorf("%s does not accept commitments", fullResourceName)
+	}
+	if err := validateCommitmentDuration(fullResourceName, cfg, duration); err != nil {
+		return err
+	}
+
+	// durations with months and years have no fixed length, so compare them
+	// relative to the start of the commitment
+	start := commitment.CreatedAt.Time
+	if commitment.ConfirmedAt != nil {
+		start = commitment.ConfirmedAt.Time
+	}
+	if !duration.AddTo(start).After(commitment.Duration.AddTo(start)) {
+		return fmt.Errorf("the duration of commitment %s can only be extended, but %q is not longer than %q",
+			commitment.UUID, duration.String(), commitment.Duration.String())
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ func newDomainCmd() *cobra.Command {
 	// Subcommands
 	cmd.AddCommand(newDomainListCmd().Command)
 	cmd.AddCommand(newDomainShowCmd().Command)
+	cmd.AddCommand(newDomainListExpiringCommitmentsCmd().Command)
 	return cmd
 }
 
@@ -35,7 +36,7 @@ type domainListCmd struct {
 	*cobra.Command
 
 	filterFlags    resourceFilterFlags
-	outputFmtFlags resourceOutputFmtFlags
+	outputFmtFlags resourceReportOutputFmtFlags
 }
 
 func newDomainListCmd() *domainListCmd {
@@ -65,6 +66,7 @@ func (d *domainListCmd) Run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	requestPerAZReportsIfNeeded(outputOpts)
 
 	res := domains.List(cmd.Context(), limesResourcesClient, domains.ListOpts{
 		Areas:     d.filterFlags.areas,
@@ -75,8 +77,8 @@ func (d *domainListCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(res.Err, "could not get domain reports")
 	}
 
-	if d.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesReps, err := res.ExtractDomains()
@@ -94,7 +96,7 @@ type domainShowCmd struct {
 	*cobra.Command
 
 	filterFlags    resourceFilterFlags
-	outputFmtFlags resourceOutputFmtFlags
+	outputFmtFlags resourceReportOutputFmtFlags
 }
 
 func newDomainShowCmd() *domainShowCmd {
@@ -124,6 +126,7 @@ func (d *domainShowCmd) Run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	requestPerAZReportsIfNeeded(outputOpts)
 
 	nameOrID := ""
 	if len(args) > 0 {
@@ -143,8 +146,8 @@ func (d *domainShowCmd) Run(cmd *cobra.Command, args []string) error {
 		return util.WrapError(res.Err, "could not get domain report")
 	}
 
-	if d.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesRep, err := res.Extract()
@@ -154,3 +157,59 @@ func (d *domainShowCmd) Run(cmd *cobra.Command, args []string) error {
 
 	return writeReports(outputOpts, core.DomainReport{DomainReport: limesRep})
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// Domain list expiring commitments.
+
+type domainListExpiringCommitmentsCmd struct {
+	*cobra.Command
+
+	expiryFlags    expiringCommitmentsFlags
+	outputFmtFlags commitmentOutputFmtFlags
+}
+
+func newDomainListExpiringCommitmentsCmd() *domainListExpiringCommitmentsCmd {
+	domainListExpiringCommitments := &domainListExpiringCommitmentsCmd{}
+	cmd := &cobra.Command{
+		Use:   "list-expiring-commitments [name or ID]",
+		Short: "Display the commitments that expire soon in all projects of a domain",
+		Long: `Display the commitments that expire soon in all projects of a domain.
+
+By default, a summary is shown that aggregates the commitments by expiry month,
+service, resource and availability zone. Use '--detail' to list every
+commitment; combine it with '--names' to see project names.
+With '--format ics', every commitment is exported as iCalendar events instead.
+
+This command requires a domain-admin token.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    domainListExpiringCommitments.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	domainListExpiringCommitments.expiryFlags.AddToCmd(cmd)
+	domainListExpiringCommitments.outputFmtFlags.AddToCmd(cmd)
+
+	domainListExpiringCommitments.Command = cmd
+	return domainListExpiringCommitments
+}
+
+// Run is called by Cobra when this command is executed.
+func (d *domainListExpiringCommitmentsCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := d.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	projectInfos, err := findProjectsInDomain(cmd.Context(), nameOrID)
+	if err != nil {
+		return err
+	}
+
+	return writeExpiringCommitments(cmd.Context(), projectInfos, d.expiryFlags, outputOpts)
+}
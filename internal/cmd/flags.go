@@ -5,7 +5,12 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 
+	"github.com/fatih/color"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
 	"github.com/spf13/cobra"
 
 	"github.com/sapcc/limesctl/v3/internal/core"
@@ -55,30 +60,115 @@ func (f *rateFilterFlags) AddToCmd(cmd *cobra.Command) {
 	f.commonFilterFlags.AddToCmd(cmd)
 }
 
+// commitmentFilterFlags define filters for commitment listings. Limes does not
+// support these as query parameters, so they are applied on the client side.
+type commitmentFilterFlags struct {
+	services  []string
+	resources []string
+	statuses  []string
+}
+
+// AddToCmd adds the commitmentFilterFlags to the cobra.Command.
+func (f *commitmentFilterFlags) AddToCmd(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.services, "services", nil, "service types (comma separated list)")
+	cmd.Flags().StringSliceVar(&f.resources, "resources", nil, "resource names (comma separated list)")
+	cmd.Flags().StringSliceVar(&f.statuses, "status", nil, "commitment status, e.g. confirmed, pending, planned (comma separated list)")
+}
+
+func (f commitmentFilterFlags) validate() error {
+	for _, s := range f.statuses {
+		if !liquid.CommitmentStatus(s).IsValid() {
+			return fmt.Errorf("invalid commitment status: %q", s)
+		}
+	}
+	return nil
+}
+
+// filter returns the commitments that match all given filters.
+func (f commitmentFilterFlags) filter(in []limesresources.Commitment) []limesresources.Commitment {
+	out := make([]limesresources.Commitment, 0, len(in))
+	for _, c := range in {
+		if len(f.services) > 0 && !slices.Contains(f.services, string(c.ServiceType)) {
+			continue
+		}
+		if len(f.resources) > 0 && !slices.Contains(f.resources, string(c.ResourceName)) {
+			continue
+		}
+		if len(f.statuses) > 0 && !slices.Contains(f.statuses, string(c.Status)) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// subdivisionFlags define whether the subresources or subcapacities of
+// resources are shown instead of the resources themselves.
+type subdivisionFlags struct {
+	enabled bool
+	where   []string
+}
+
+// AddToCmd adds the subdivisionFlags to the cobra.Command. The name of the
+// flag that enables them is either "subresources" or "subcapacities".
+func (s *subdivisionFlags) AddToCmd(cmd *cobra.Command, name string) {
+	cmd.Flags().BoolVar(&s.enabled, name, false, fmt.Sprintf("show the %s of each resource, with their attributes as columns", name))
+	cmd.Flags().StringArrayVar(&s.where, "where", nil, fmt.Sprintf("only show %s whose column has the given value, e.g. attributes.os_type=linux (can be given multiple times)", name))
+}
+
+func (s subdivisionFlags) validate(name string, opts *core.OutputOpts) ([]core.SubdivisionFilter, error) {
+	if !s.enabled {
+		if len(s.where) > 0 {
+			return nil, fmt.Errorf("'--where' can only be used together with '--%s'", name)
+		}
+		return nil, nil
+	}
+	if opts.PerAZ || opts.Commitments {
+		return nil, fmt.Errorf("'--%s' cannot be combined with '--per-az' or '--commitments'", name)
+	}
+
+	filters := make([]core.SubdivisionFilter, 0, len(s.where))
+	for _, input := range s.where {
+		f, err := core.ParseSubdivisionFilter(input)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // CLI output format flags.
 
 type commonOutputFmtFlags struct {
-	format core.OutputFormat
-	names  bool
-	long   bool
+	format  core.OutputFormat
+	names   bool
+	long    bool
+	noColor bool
 }
 
 // AddToCmd adds the commonOutputFmtFlags to the cobra.Command.
 func (o *commonOutputFmtFlags) AddToCmd(cmd *cobra.Command) {
-	cmd.Flags().VarP(&o.format, "format", "f", "output format: table (default), json, csv")
-	cmd.Flags().BoolVar(&o.names, "names", false, "show output with names instead of UUIDs. Not valid for 'json' output format")
-	cmd.Flags().BoolVar(&o.long, "long", false, "show detailed output. Not valid for 'json' output format")
+	cmd.Flags().VarP(&o.format, "format", "f", "output format: table (default), json, yaml, csv, json-rows, ndjson, template=..., template-file=..., jsonpath=...")
+	cmd.Flags().BoolVar(&o.names, "names", false, "show output with names instead of UUIDs. Not valid for 'json' and 'yaml' output formats")
+	cmd.Flags().BoolVar(&o.long, "long", false, "show detailed output. Not valid for 'json' and 'yaml' output formats")
+	cmd.Flags().BoolVar(&o.noColor, "no-color", false, "do not highlight values in table output (colors are also disabled if NO_COLOR is set or stdout is not a terminal)")
 }
 
 func (o commonOutputFmtFlags) validate() (*core.OutputOpts, error) {
 	// Catch errors.
+	if o.format == core.OutputFormatICS {
+		return nil, errors.New("'ics' output format is only supported for commitment listings")
+	}
 	if o.long && o.names {
 		return nil, errors.New("'--long' and '--names' flags are mutually exclusive, i.e. use one, not both")
 	}
 
 	opts := &core.OutputOpts{
 		Fmt: o.format,
+		// colors are only used for tables, and only if stdout is a terminal
+		Color: (o.format == "" || o.format == core.OutputFormatTable) && !o.noColor && !color.NoColor,
 	}
 	switch {
 	case o.long:
@@ -114,6 +204,84 @@ func (o resourceOutputFmtFlags) validate() (*core.OutputOpts, error) {
 	return opts, nil
 }
 
+// resourceReportOutputFmtFlags define how the app will print resource reports
+// of clusters, domains and projects. In addition to the resourceOutputFmtFlags,
+// they support a breakdown by availability zone and commitment columns.
+type resourceReportOutputFmtFlags struct {
+	resourceOutputFmtFlags
+	perAZ                 bool
+	commitments           bool
+	commitmentsByDuration bool
+	utilization           bool
+	warnAt                float64
+	critAt                float64
+	sortBy                string
+}
+
+// AddToCmd adds the resourceReportOutputFmtFlags to the cobra.Command.
+func (o *resourceReportOutputFmtFlags) AddToCmd(cmd *cobra.Command) {
+	o.resourceOutputFmtFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVar(&o.perAZ, "per-az", false, "show one row per service, resource and availability zone")
+	cmd.Flags().BoolVar(&o.commitments, "commitments", false, "show total committed, pending and planned amounts")
+	cmd.Flags().BoolVar(&o.commitmentsByDuration, "commitments-by-duration", false, "like '--commitments', but also show the amounts for each commitment duration")
+	cmd.Flags().BoolVar(&o.utilization, "utilization", false, "show usage as a percentage of quota and (for clusters) of capacity")
+	cmd.Flags().Float64Var(&o.warnAt, "warn-at", 0, "highlight utilization percentages from this value upwards in yellow, e.g. 80. Implies '--utilization'")
+	cmd.Flags().Float64Var(&o.critAt, "crit-at", 0, "highlight utilization percentages from this value upwards in red, e.g. 95. Implies '--utilization'")
+	cmd.Flags().StringVar(&o.sortBy, "sort-by", "", "sort rows across all reports. Supported values: utilization (highest first), which implies '--utilization'. Not valid for 'json' and 'yaml' output formats")
+}
+
+func (o resourceReportOutputFmtFlags) validate() (*core.OutputOpts, error) {
+	opts, err := o.resourceOutputFmtFlags.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.sortBy != "" && o.sortBy != core.SortByUtilization {
+		return nil, fmt.Errorf("invalid value for '--sort-by': %q", o.sortBy)
+	}
+	if o.warnAt < 0 || o.critAt < 0 {
+		return nil, errors.New("'--warn-at' and '--crit-at' must not be negative")
+	}
+	if o.warnAt > 0 && o.critAt > 0 && o.warnAt > o.critAt {
+		return nil, errors.New("'--warn-at' must not be greater than '--crit-at'")
+	}
+
+	opts.PerAZ = o.perAZ
+	opts.Commitments = o.commitments || o.commitmentsByDuration
+	opts.CommitmentsByDuration = o.commitmentsByDuration
+	opts.Utilization = o.utilization || o.warnAt > 0 || o.critAt > 0 || o.sortBy != ""
+	opts.WarnAt = o.warnAt
+	opts.CritAt = o.critAt
+	opts.SortBy = o.sortBy
+	return opts, nil
+}
+
+// commitmentOutputFmtFlags define how the app will print commitment listings.
+// In addition to the resourceOutputFmtFlags, they support the 'ics' format.
+type commitmentOutputFmtFlags struct {
+	resourceOutputFmtFlags
+}
+
+// AddToCmd adds the commitmentOutputFmtFlags to the cobra.Command.
+func (o *commitmentOutputFmtFlags) AddToCmd(cmd *cobra.Command) {
+	o.resourceOutputFmtFlags.AddToCmd(cmd)
+	cmd.Flags().Lookup("format").Usage = "output format: table (default), json, yaml, csv, json-rows, ndjson, ics, template=..., template-file=..., jsonpath=..."
+}
+
+func (o commitmentOutputFmtFlags) validate() (*core.OutputOpts, error) {
+	format := o.format
+	if format == core.OutputFormatICS {
+		o.format = core.OutputFormatTable // o is a copy
+	}
+	opts, err := o.resourceOutputFmtFlags.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Fmt = format
+	return opts, nil
+}
+
 // rateOutputFmtFlags define how the app will print rate limit data.
 type rateOutputFmtFlags struct {
 	commonOutputFmtFlags
@@ -133,6 +301,7 @@ type liquidOperationFlags struct {
 	endpoint string
 	compare  bool
 	body     string
+	format   core.OutputFormat
 }
 
 // AddToCmd adds the liquidOperationFlags to the cobra.Command.
@@ -140,6 +309,24 @@ func (l *liquidOperationFlags) AddToCmd(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&l.endpoint, "endpoint", "e", "", "query a liquid running locally")
 	cmd.Flags().BoolVarP(&l.compare, "compare", "c", false, "query both the liquid in the cluster and the liquid running locally. Renders the diff of both responses. Requires --endpoint to be set.")
 	cmd.Flags().StringVarP(&l.body, "body", "b", "", "use a custom request body generated from provided json structure")
+	l.format = core.OutputFormatJSON
+	cmd.Flags().VarP(&l.format, "format", "f", "output format: json (default), yaml, template=..., template-file=..., jsonpath=...")
+}
+
+func (l liquidOperationFlags) validate() error {
+	if !isStructuredFormat(l.format) {
+		return fmt.Errorf("%q output format is not supported for LIQUID responses", l.format)
+	}
+	return nil
+}
+
+// outputFormat returns the format in which LIQUID responses are printed, or an
+// empty string if they are only compared.
+func (l liquidOperationFlags) outputFormat() core.OutputFormat {
+	if l.compare {
+		return ""
+	}
+	return l.format
 }
 
 // liquidQuotaOperationFlags
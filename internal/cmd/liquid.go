@@ -20,10 +20,14 @@ import (
 	"github.com/sapcc/go-bits/liquidapi"
 	"github.com/spf13/cobra"
 
+	"github.com/sapcc/limesctl/v3/internal/core"
 	"github.com/sapcc/limesctl/v3/internal/util"
 )
 
-func prettyPrint(obj any) error {
+func prettyPrint(obj any, format core.OutputFormat) error {
+	if format != core.OutputFormatJSON {
+		return writeStructured(&core.OutputOpts{Fmt: format}, obj)
+	}
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(obj)
@@ -82,7 +86,8 @@ func newLiquidServiceInfoCmd() *liquidServiceInfoCmd {
 }
 
 // GetLiquidServiceInfo retrieves the liquid.ServiceInfo from the liquid client and does validation and pretty printing on it.
-func GetLiquidServiceInfo(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, output bool) (liquid.ServiceInfo, error) {
+// If outputFmt is empty, the result is not printed.
+func GetLiquidServiceInfo(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, outputFmt core.OutputFormat) (liquid.ServiceInfo, error) {
 	liquidClient, err := liquidapi.NewClient(provider, gophercloud.EndpointOpts{}, opts)
 	if err != nil {
 		return liquid.ServiceInfo{}, util.WrapError(err, "could not instantiate new LIQUID client")
@@ -95,8 +100,8 @@ func GetLiquidServiceInfo(provider *gophercloud.ProviderClient, opts liquidapi.C
 	if err != nil {
 		return liquid.ServiceInfo{}, util.WrapError(err, "received an invalid service info")
 	}
-	if output {
-		err = prettyPrint(serviceInfo)
+	if outputFmt != "" {
+		err = prettyPrint(serviceInfo, outputFmt)
 		if err != nil {
 			return liquid.ServiceInfo{}, util.WrapError(err, "could not output service info")
 		}
@@ -110,6 +115,9 @@ func (c *liquidServiceInfoCmd) Run(cmd *cobra.Command, args []string) error {
 	if len(args) == 1 {
 		serviceType = args[0]
 	}
+	if err := c.flags.validate(); err != nil {
+		return err
+	}
 	endpoint := c.flags.endpoint
 	compare := c.flags.compare
 	if compare && (endpoint == "" || serviceType == "") {
@@ -127,7 +135,7 @@ func (c *liquidServiceInfoCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var serviceInfo liquid.ServiceInfo
 	if serviceType != "" {
-		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), !compare)
+		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -135,7 +143,7 @@ func (c *liquidServiceInfoCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var localServiceInfo liquid.ServiceInfo
 	if endpoint != "" {
-		localServiceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), !compare)
+		localServiceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -182,7 +190,8 @@ func newLiquidReportCapacityCmd() *liquidReportCapacityCmd {
 }
 
 // GetLiquidCapacityReport retrieves the liquid.ServiceCapacityReport from the liquid client and does validation and pretty printing on it.
-func GetLiquidCapacityReport(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, serviceCapacityRequest *liquid.ServiceCapacityRequest, serviceInfo liquid.ServiceInfo, output bool) (liquid.ServiceCapacityReport, error) {
+// If outputFmt is empty, the result is not printed.
+func GetLiquidCapacityReport(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, serviceCapacityRequest *liquid.ServiceCapacityRequest, serviceInfo liquid.ServiceInfo, outputFmt core.OutputFormat) (liquid.ServiceCapacityReport, error) {
 	liquidClient, err := liquidapi.NewClient(provider, gophercloud.EndpointOpts{}, opts)
 	if err != nil {
 		return liquid.ServiceCapacityReport{}, util.WrapError(err, "could not instantiate new LIQUID client")
@@ -207,8 +216,8 @@ func GetLiquidCapacityReport(provider *gophercloud.ProviderClient, opts liquidap
 		}
 	}
 
-	if output {
-		err = prettyPrint(serviceCapacityReport)
+	if outputFmt != "" {
+		err = prettyPrint(serviceCapacityReport, outputFmt)
 		if err != nil {
 			return liquid.ServiceCapacityReport{}, util.WrapError(err, "could not output service capacity report")
 		}
@@ -220,6 +229,9 @@ func GetLiquidCapacityReport(provider *gophercloud.ProviderClient, opts liquidap
 func (c *liquidReportCapacityCmd) Run(cmd *cobra.Command, args []string) error {
 	serviceType := args[0]
 
+	if err := c.flags.validate(); err != nil {
+		return err
+	}
 	endpoint := c.flags.endpoint
 	compare := c.flags.compare
 	if compare && (endpoint == "" || serviceType == "") {
@@ -257,11 +269,11 @@ func (c *liquidReportCapacityCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var serviceCapacityReport liquid.ServiceCapacityReport
 	if compare || endpoint == "" {
-		serviceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), false)
+		serviceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), "")
 		if err != nil {
 			return err
 		}
-		serviceCapacityReport, err = GetLiquidCapacityReport(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), serviceCapacityRequest, serviceInfo, !compare)
+		serviceCapacityReport, err = GetLiquidCapacityReport(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), serviceCapacityRequest, serviceInfo, c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -269,11 +281,11 @@ func (c *liquidReportCapacityCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var localServiceCapacityReport liquid.ServiceCapacityReport
 	if endpoint != "" {
-		localServiceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), false)
+		localServiceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), "")
 		if err != nil {
 			return err
 		}
-		localServiceCapacityReport, err = GetLiquidCapacityReport(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), serviceCapacityRequest, localServiceInfo, !compare)
+		localServiceCapacityReport, err = GetLiquidCapacityReport(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), serviceCapacityRequest, localServiceInfo, c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -320,7 +332,8 @@ func newLiquidReportUsageCmd() *liquidReportUsageCmd {
 }
 
 // GetLiquidUsageReport retrieves the liquid.ServiceUsageReport from the liquid client and does validation and pretty printing on it.
-func GetLiquidUsageReport(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, projectID string, serviceUsageRequest *liquid.ServiceUsageRequest, serviceInfo liquid.ServiceInfo, output bool) (liquid.ServiceUsageReport, error) {
+// If outputFmt is empty, the result is not printed.
+func GetLiquidUsageReport(provider *gophercloud.ProviderClient, opts liquidapi.ClientOpts, ctx context.Context, projectID string, serviceUsageRequest *liquid.ServiceUsageRequest, serviceInfo liquid.ServiceInfo, outputFmt core.OutputFormat) (liquid.ServiceUsageReport, error) {
 	liquidClient, err := liquidapi.NewClient(provider, gophercloud.EndpointOpts{}, opts)
 	if err != nil {
 		return liquid.ServiceUsageReport{}, util.WrapError(err, "could not instantiate new LIQUID client")
@@ -333,8 +346,8 @@ func GetLiquidUsageReport(provider *gophercloud.ProviderClient, opts liquidapi.C
 	if err != nil {
 		return liquid.ServiceUsageReport{}, util.WrapError(err, "received an invalid service usage report")
 	}
-	if output {
-		err = prettyPrint(serviceUsageReport)
+	if outputFmt != "" {
+		err = prettyPrint(serviceUsageReport, outputFmt)
 		if err != nil {
 			return liquid.ServiceUsageReport{}, util.WrapError(err, "could not output service usage report")
 		}
@@ -347,6 +360,9 @@ func (c *liquidReportUsageCmd) Run(cmd *cobra.Command, args []string) error {
 	serviceType := args[0]
 	projectID := args[1]
 
+	if err := c.flags.validate(); err != nil {
+		return err
+	}
 	endpoint := c.flags.endpoint
 	compare := c.flags.compare
 	if compare && (endpoint == "" || serviceType == "") {
@@ -384,11 +400,11 @@ func (c *liquidReportUsageCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var serviceUsageReport liquid.ServiceUsageReport
 	if compare || endpoint == "" {
-		serviceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), false)
+		serviceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), "")
 		if err != nil {
 			return err
 		}
-		serviceUsageReport, err = GetLiquidUsageReport(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), projectID, serviceUsageRequest, serviceInfo, !compare)
+		serviceUsageReport, err = GetLiquidUsageReport(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), projectID, serviceUsageRequest, serviceInfo, c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -396,11 +412,11 @@ func (c *liquidReportUsageCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var localServiceusageReport liquid.ServiceUsageReport
 	if endpoint != "" {
-		localServiceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), false)
+		localServiceInfo, err := GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), "")
 		if err != nil {
 			return err
 		}
-		localServiceusageReport, err = GetLiquidUsageReport(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), projectID, serviceUsageRequest, localServiceInfo, !compare)
+		localServiceusageReport, err = GetLiquidUsageReport(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), projectID, serviceUsageRequest, localServiceInfo, c.flags.outputFormat())
 		if err != nil {
 			return err
 		}
@@ -488,9 +504,9 @@ func (c *liquidSetQuotaCmd) Run(cmd *cobra.Command, args []string) error {
 
 	var serviceInfo liquid.ServiceInfo
 	if endpoint == "" {
-		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), false)
+		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{ServiceType: "liquid-" + serviceType}, cmd.Context(), "")
 	} else {
-		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), false)
+		serviceInfo, err = GetLiquidServiceInfo(provider, liquidapi.ClientOpts{EndpointOverride: endpoint}, cmd.Context(), "")
 	}
 	if err != nil {
 		return err
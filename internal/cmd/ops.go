@@ -4,16 +4,21 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
+	identityprojects "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
 	"github.com/sapcc/go-api-declarations/limes"
 	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
 	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/projects"
 	"github.com/spf13/cobra"
 
 	"github.com/sapcc/limesctl/v3/internal/auth"
+	"github.com/sapcc/limesctl/v3/internal/core"
 	"github.com/sapcc/limesctl/v3/internal/util"
 )
 
@@ -26,30 +31,65 @@ func newOpsCmd() *cobra.Command {
 	// Flags
 	doNotSortFlags(cmd)
 	// Subcommands
-	cmd.AddCommand(newOpsValidateQuotaOverridesCmd())
+	cmd.AddCommand(newOpsValidateQuotaOverridesCmd().Command)
+	cmd.AddCommand(newOpsGenerateQuotaOverridesCmd().Command)
+	cmd.AddCommand(newOpsDiffQuotaOverridesCmd().Command)
 	return cmd
 }
 
-func newOpsValidateQuotaOverridesCmd() *cobra.Command {
-	return &cobra.Command{
+///////////////////////////////////////////////////////////////////////////////
+// Ops validate quota overrides.
+
+type opsValidateQuotaOverridesCmd struct {
+	*cobra.Command
+
+	checkNames bool
+	format     string
+}
+
+func newOpsValidateQuotaOverridesCmd() *opsValidateQuotaOverridesCmd {
+	opsValidateQuotaOverrides := &opsValidateQuotaOverridesCmd{}
+	cmd := &cobra.Command{
 		Use:   "validate-quota-overrides path",
 		Short: "Validate a quota-overrides.json file for usage with an existing Limes instance",
 		Long: `Validate a quota-overrides.json file for usage with an existing Limes instance.
+The file may also be given in YAML format.
+
+Service types, resource names and values are checked against the resource report
+of the project from the current token scope. With '--check-names', the domain
+and project names are also looked up in Keystone, which requires permissions to
+list projects in the respective domains. For unknown names, close matches are
+suggested if there are any.
 
 Requires project member permissions.`,
 		Args:    cobra.ExactArgs(1),
 		PreRunE: authWithLimesResources,
-		RunE:    runValidateQuotaOverrides,
+		RunE:    opsValidateQuotaOverrides.Run,
 	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	cmd.Flags().BoolVar(&opsValidateQuotaOverrides.checkNames, "check-names", false, "check that the domains and projects exist in Keystone")
+	cmd.Flags().StringVar(&opsValidateQuotaOverrides.format, "format", "text", "format for the findings: text, json, yaml")
+
+	opsValidateQuotaOverrides.Command = cmd
+	return opsValidateQuotaOverrides
 }
 
-func runValidateQuotaOverrides(cmd *cobra.Command, args []string) error {
-	buf, err := os.ReadFile(args[0])
+// Run is called by Cobra when this command is executed.
+func (o *opsValidateQuotaOverridesCmd) Run(cmd *cobra.Command, args []string) error {
+	outputFmt := core.OutputFormat(o.format)
+	if o.format != "text" && !isStructuredFormat(outputFmt) {
+		return fmt.Errorf("invalid value for --format: %q", o.format)
+	}
+	path := args[0]
+	buf, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
 
-	// get resource report for the project from the current token scope
+	// get resource report for the project from the current token scope; it
+	// serves as a hint for which services/resources exist with which units
 	pInfo, err := auth.FindProject(cmd.Context(), identityClient, "", "")
 	if err != nil {
 		return err
@@ -59,30 +99,305 @@ func runValidateQuotaOverrides(cmd *cobra.Command, args []string) error {
 		return util.WrapError(err, "could not get project report")
 	}
 
-	// parse quota-overrides.json file using the existing report as
-	// a hint for which services/resources exist with which units
-	getUnit := func(serviceType limes.ServiceType, resourceName limesresources.ResourceName) (limes.Unit, error) {
-		srvReport := report.Services[serviceType]
-		if srvReport == nil {
-			return limes.UnitNone, fmt.Errorf("%q is not a valid service", serviceType)
+	validator := core.QuotaOverridesValidator{Report: report}
+	if o.checkNames {
+		addKeystoneNameChecks(cmd.Context(), &validator)
+	}
+	findings, err := validator.Validate(buf)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredFormat(outputFmt) {
+		if findings == nil {
+			findings = []core.QuotaOverridesFinding{}
+		}
+		err = writeStructured(&core.OutputOpts{Fmt: outputFmt}, struct {
+			Path     string                       `json:"path"`
+			Findings []core.QuotaOverridesFinding `json:"findings"`
+		}{path, findings})
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Fprintf(os.Stderr, "ERROR: %s:%s\n", path, f.String())
 		}
-		resReport := srvReport.Resources[resourceName]
-		if resReport == nil {
-			fullResourceName := fmt.Sprintf("%s/%s", serviceType, resourceName)
-			return limes.UnitNone, fmt.Errorf("%q is not a valid resource", fullResourceName)
+	}
+
+	if len(findings) > 0 {
+		return errors.New("validation failed")
+	}
+	return nil
+}
+
+// addKeystoneNameChecks makes the validator look up domain and project names
+// in Keystone. Each name is only looked up once.
+func addKeystoneNameChecks(ctx context.Context, validator *core.QuotaOverridesValidator) {
+	type lookupResult struct {
+		id  string
+		err error
+	}
+	domainCache := make(map[string]lookupResult)
+	findDomainID := func(domainName string) (string, error) {
+		r, exists := domainCache[domainName]
+		if !exists {
+			r.id, r.err = auth.FindDomainID(ctx, identityClient, domainName)
+			domainCache[domainName] = r
+		}
+		return r.id, r.err
+	}
+	validator.CheckDomain = func(domainName string) error {
+		_, err := findDomainID(domainName)
+		return err
+	}
+
+	projectCache := make(map[[2]string]error)
+	validator.CheckProject = func(domainName, projectName string) error {
+		key := [2]string{domainName, projectName}
+		err, exists := projectCache[key]
+		if !exists {
+			_, err = auth.FindProject(ctx, identityClient, domainName, projectName)
+			projectCache[key] = err
+		}
+		return err
+	}
+
+	projectNamesCache := make(map[string][]string)
+	validator.ProjectNames = func(domainName string) []string {
+		names, exists := projectNamesCache[domainName]
+		if exists {
+			return names
+		}
+		// failure to list projects only means that no suggestions are made
+		domainID, err := findDomainID(domainName)
+		if err == nil {
+			var pList []identityprojects.Project
+			page, err := identityprojects.List(identityClient, identityprojects.ListOpts{DomainID: domainID}).AllPages(ctx)
+			if err == nil {
+				pList, err = identityprojects.ExtractProjects(page)
+			}
+			if err == nil {
+				for _, p := range pList {
+					names = append(names, p.Name)
+				}
+			}
+		}
+		slices.Sort(names)
+		projectNamesCache[domainName] = names
+		return names
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Ops generate quota overrides.
+
+type opsGenerateQuotaOverridesCmd struct {
+	*cobra.Command
+
+	domainNameOrID string
+	projects       []string
+	services       []string
+}
+
+func newOpsGenerateQuotaOverridesCmd() *opsGenerateQuotaOverridesCmd {
+	opsGenerateQuotaOverrides := &opsGenerateQuotaOverridesCmd{}
+	cmd := &cobra.Command{
+		Use:   "generate-quota-overrides",
+		Short: "Generate a quota-overrides.json file from the current quotas of projects",
+		Long: `Generate a quota-overrides.json file from the current quotas of the projects in
+a domain, e.g. to carry them over when migrating projects between clusters. The
+file is written to stdout.
+
+Values of measured resources are rendered in the best human-readable unit.
+Resources that do not track quota are skipped.
+
+Requires a domain-admin token.`,
+		Args:    cobra.NoArgs,
+		PreRunE: authWithLimesResources,
+		RunE:    opsGenerateQuotaOverrides.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	cmd.Flags().StringVarP(&opsGenerateQuotaOverrides.domainNameOrID, "domain", "d", "", "name or ID of the domain")
+	cmd.Flags().StringSliceVar(&opsGenerateQuotaOverrides.projects, "projects", nil, "only include these projects, given by name or ID (comma separated list)")
+	cmd.Flags().StringSliceVar(&opsGenerateQuotaOverrides.services, "services", nil, "only include these service types (comma separated list)")
+	cobra.CheckErr(cmd.MarkFlagRequired("domain"))
+
+	opsGenerateQuotaOverrides.Command = cmd
+	return opsGenerateQuotaOverrides
+}
+
+// Run is called by Cobra when this command is executed.
+func (o *opsGenerateQuotaOverridesCmd) Run(cmd *cobra.Command, _ []string) error {
+	domainID, err := auth.FindDomainID(cmd.Context(), identityClient, o.domainNameOrID)
+	if err != nil {
+		return err
+	}
+	domainName, err := auth.FindDomainName(cmd.Context(), identityClient, domainID)
+	if err != nil {
+		return err
+	}
+
+	reports, err := projects.List(cmd.Context(), limesResourcesClient, domainID, projects.ListOpts{
+		Services: util.CastStringsTo[limes.ServiceType](o.services),
+	}).ExtractProjects()
+	if err != nil {
+		return util.WrapError(err, "could not get project reports")
+	}
+
+	overrides := make(core.QuotaOverrides)
+	found := make(map[string]bool)
+	for _, rep := range reports {
+		if len(o.projects) > 0 {
+			if !slices.Contains(o.projects, rep.Name) && !slices.Contains(o.projects, rep.UUID) {
+				continue
+			}
+			found[rep.Name] = true
+			found[rep.UUID] = true
+		}
+		overrides.AddProjectReport(domainName, rep)
+	}
+	for _, nameOrID := range o.projects {
+		if !found[nameOrID] {
+			return fmt.Errorf("project %q does not exist in domain %s", nameOrID, domainName)
+		}
+	}
+
+	buf, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return util.WrapError(err, "could not marshal JSON")
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(buf))
+	if err != nil {
+		return util.WrapError(err, "could not write JSON data")
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Ops diff quota overrides.
+
+type opsDiffQuotaOverridesCmd struct {
+	*cobra.Command
+
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newOpsDiffQuotaOverridesCmd() *opsDiffQuotaOverridesCmd {
+	opsDiffQuotaOverrides := &opsDiffQuotaOverridesCmd{}
+	cmd := &cobra.Command{
+		Use:   "diff-quota-overrides path",
+		Short: "Compare a quota-overrides.json file with the current quotas of the projects in it",
+		Long: `Compare a quota-overrides.json file with the current quotas of the projects in it.
+For each resource in the file, the current quota, the override and the current
+usage are shown, together with a warning if the override is below the usage.
+
+Requires a cloud-admin token.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    opsDiffQuotaOverrides.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	opsDiffQuotaOverrides.outputFmtFlags.AddToCmd(cmd)
+
+	opsDiffQuotaOverrides.Command = cmd
+	return opsDiffQuotaOverrides
+}
+
+// Run is called by Cobra when this command is executed.
+func (o *opsDiffQuotaOverridesCmd) Run(cmd *cobra.Command, args []string) error {
+	outputOpts, err := o.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+	buf, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// the units of the resources are the same in all projects, so the report
+	// for the project from the current token scope can be used for parsing
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, "", "")
+	if err != nil {
+		return err
+	}
+	tokenReport, err := projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{}).Extract()
+	if err != nil {
+		return util.WrapError(err, "could not get project report")
+	}
+	getUnit := func(serviceType limes.ServiceType, resourceName limesresources.ResourceName) (limes.Unit, error) {
+		resReport, err := findResourceReport(tokenReport, serviceType, resourceName)
+		if err != nil {
+			return limes.UnitNone, err
 		}
 		if resReport.Quota == nil {
 			return limes.UnitNone, fmt.Errorf("%s/%s does not track quota", serviceType, resourceName)
 		}
 		return resReport.Unit, nil
 	}
-	_, errs := limesresources.ParseQuotaOverrides(buf, getUnit)
+	overrides, errs := limesresources.ParseQuotaOverrides(buf, getUnit)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
 		}
-		return errors.New("validation failed")
+		return errors.New("could not parse quota overrides")
+	}
+
+	var (
+		reports     []core.QuotaOverridesDiffReport
+		failedCount int
+	)
+	for _, domainName := range sortedKeys(overrides) {
+		for _, projectName := range sortedKeys(overrides[domainName]) {
+			projectOverrides := overrides[domainName][projectName]
+			pInfo, err := auth.FindProject(cmd.Context(), identityClient, domainName, projectName)
+			if err == nil {
+				var report *limesresources.ProjectReport
+				report, err = projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{
+					Services: sortedKeys(projectOverrides),
+				}).Extract()
+				if err == nil {
+					reports = append(reports, core.NewQuotaOverridesDiffReport(pInfo.DomainID, pInfo.DomainName, report, projectOverrides))
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "ERROR: project %s/%s: %s\n", domainName, projectName, err.Error())
+				failedCount++
+			}
+		}
+	}
+
+	var belowUsageCount int
+	for _, rep := range reports {
+		for _, d := range rep.Resources {
+			if d.BelowUsage {
+				belowUsageCount++
+			}
+		}
+	}
+	if belowUsageCount > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: %d override(s) are below the current usage\n", belowUsageCount)
 	}
 
+	if isStructuredFormat(outputOpts.Fmt) {
+		err = writeStructured(outputOpts, map[string]any{"projects": reports})
+	} else if len(reports) > 0 {
+		renderers := make([]core.LimesReportRenderer, 0, len(reports))
+		for _, rep := range reports {
+			renderers = append(renderers, rep)
+		}
+		err = writeReports(outputOpts, renderers...)
+	}
+	if err != nil {
+		return err
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("could not compare quota overrides for %d project(s)", failedCount)
+	}
 	return nil
 }
@@ -4,16 +4,27 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sapcc/go-api-declarations/limes"
+	limesrates "github.com/sapcc/go-api-declarations/limes/rates"
 	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
 	ratesProjects "github.com/sapcc/gophercloud-sapcc/v2/rates/v1/projects"
 	"github.com/sapcc/gophercloud-sapcc/v2/resources/v1/projects"
 	"github.com/spf13/cobra"
 
 	"github.com/sapcc/limesctl/v3/internal/auth"
 	"github.com/sapcc/limesctl/v3/internal/core"
+	"github.com/sapcc/limesctl/v3/internal/limesapi"
 	"github.com/sapcc/limesctl/v3/internal/util"
 )
 
@@ -28,9 +39,15 @@ func newProjectCmd() *cobra.Command {
 	// Subcommands
 	cmd.AddCommand(newProjectListCmd().Command)
 	cmd.AddCommand(newProjectListRatesCmd().Command)
+	cmd.AddCommand(newProjectListCommitmentsCmd().Command)
+	cmd.AddCommand(newProjectCreateCommitmentCmd().Command)
+	cmd.AddCommand(newProjectDeleteCommitmentCmd().Command)
 	cmd.AddCommand(newProjectShowCmd().Command)
 	cmd.AddCommand(newProjectShowRatesCmd().Command)
 	cmd.AddCommand(newProjectSyncCmd().Command)
+	cmd.AddCommand(newProjectSetMaxQuotaCmd().Command)
+	cmd.AddCommand(newProjectSetAutogrowthCmd().Command)
+	cmd.AddCommand(newProjectSetRatesCmd().Command)
 	return cmd
 }
 
@@ -50,6 +67,53 @@ func (pf *projectFlags) validateWithNameID(nameOrID string) error {
 	return nil
 }
 
+// projectScopeFlags identify a project through flags, for commands whose
+// positional arguments are not project names or IDs.
+type projectScopeFlags struct {
+	projectFlags
+	ProjectNameOrID string
+}
+
+// AddToCmd adds the projectScopeFlags to the cobra.Command.
+func (pf *projectScopeFlags) AddToCmd(cmd *cobra.Command) {
+	pf.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVarP(&pf.ProjectNameOrID, "project", "p", "", "name or ID of the project (default: project from current scope)")
+}
+
+// findProject validates the flags and finds the project that they identify.
+func (pf *projectScopeFlags) findProject(ctx context.Context) (*auth.ProjectInfo, error) {
+	if pf.DomainNameOrID != "" && pf.ProjectNameOrID == "" {
+		return nil, errors.New("the '--project' flag is required when using the '--domain' flag")
+	}
+	return auth.FindProject(ctx, identityClient, pf.DomainNameOrID, pf.ProjectNameOrID)
+}
+
+// getProjectResourceReport returns the report for a single resource of the
+// given project.
+func getProjectResourceReport(ctx context.Context, pInfo *auth.ProjectInfo, srvType limes.ServiceType, resName limesresources.ResourceName) (*limesresources.ProjectResourceReport, error) {
+	report, err := projects.Get(ctx, limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{
+		Services:  []limes.ServiceType{srvType},
+		Resources: []limesresources.ResourceName{resName},
+	}).Extract()
+	if err != nil {
+		return nil, util.WrapError(err, "could not get project report")
+	}
+	return findResourceReport(report, srvType, resName)
+}
+
+// findResourceReport returns the report for a single resource from a project report.
+func findResourceReport(report *limesresources.ProjectReport, srvType limes.ServiceType, resName limesresources.ResourceName) (*limesresources.ProjectResourceReport, error) {
+	srvReport := report.Services[srvType]
+	if srvReport == nil {
+		return nil, fmt.Errorf("%q is not a valid service", srvType)
+	}
+	resReport := srvReport.Resources[resName]
+	if resReport == nil {
+		return nil, fmt.Errorf("%q is not a valid resource", fmt.Sprintf("%s/%s", srvType, resName))
+	}
+	return resReport, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Project list.
 
@@ -58,7 +122,8 @@ type projectListCmd struct {
 
 	projectFlags   projectFlags
 	filterFlags    resourceFilterFlags
-	outputFmtFlags resourceOutputFmtFlags
+	driftOnly      bool
+	outputFmtFlags resourceReportOutputFmtFlags
 }
 
 func newProjectListCmd() *projectListCmd {
@@ -82,6 +147,7 @@ This command requires a domain-admin token.`,
 	doNotSortFlags(cmd)
 	projectList.projectFlags.AddToCmd(cmd)
 	projectList.filterFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVar(&projectList.driftOnly, "drift-only", false, "only show resources whose backend quota differs from the quota in Limes")
 	projectList.outputFmtFlags.AddToCmd(cmd)
 
 	projectList.Command = cmd
@@ -94,6 +160,7 @@ func (p *projectListCmd) Run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	requestPerAZReportsIfNeeded(outputOpts)
 
 	domainName := ""
 	domainID, err := auth.FindDomainID(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID)
@@ -113,8 +180,8 @@ func (p *projectListCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(res.Err, "could not get project reports")
 	}
 
-	if p.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) && !p.driftOnly {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesReps, err := res.ExtractProjects()
@@ -122,6 +189,16 @@ func (p *projectListCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(err, "could not extract project reports")
 	}
 
+	if p.driftOnly {
+		limesReps = slices.DeleteFunc(limesReps, func(rep limesresources.ProjectReport) bool {
+			core.RemoveResourcesWithoutDrift(&rep)
+			return len(rep.Services) == 0
+		})
+		if isStructuredFormat(outputOpts.Fmt) {
+			return writeStructured(outputOpts, map[string]any{"projects": limesReps})
+		}
+	}
+
 	return writeReports(outputOpts,
 		core.LimesProjectResourcesToReportRenderer(limesReps, domainID, domainName, false)...)
 }
@@ -188,8 +265,8 @@ func (p *projectListRatesCmd) Run(cmd *cobra.Command, _ []string) error {
 		return util.WrapError(res.Err, "could not get project reports")
 	}
 
-	if p.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesReps, err := res.ExtractProjects()
@@ -201,15 +278,437 @@ func (p *projectListRatesCmd) Run(cmd *cobra.Command, _ []string) error {
 		core.LimesProjectRatesToReportRenderer(limesReps, domainID, domainName, true)...)
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// Project list commitments.
+
+type projectListCommitmentsCmd struct {
+	*cobra.Command
+
+	projectFlags   projectFlags
+	filterFlags    commitmentFilterFlags
+	outputFmtFlags commitmentOutputFmtFlags
+}
+
+func newProjectListCommitmentsCmd() *projectListCommitmentsCmd {
+	projectListCommitments := &projectListCommitmentsCmd{}
+	cmd := &cobra.Command{
+		Use:   "list-commitments [name or ID]",
+		Short: "Display the commitments of a specific project",
+		Long: `Display the commitments of a specific project.
+
+The project name/ID is optional by default and limesctl will get the project
+from current scope. However, if '--domain' flag is used then either project
+name or ID is required.
+
+With '--format ics', an iCalendar file is written that contains an event for
+the expiry of each commitment, and for the confirmation deadline of each
+commitment that is not confirmed yet.
+
+This command requires a project member permissions.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    projectListCommitments.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectListCommitments.projectFlags.AddToCmd(cmd)
+	projectListCommitments.filterFlags.AddToCmd(cmd)
+	projectListCommitments.outputFmtFlags.AddToCmd(cmd)
+
+	projectListCommitments.Command = cmd
+	return projectListCommitments
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectListCommitmentsCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := p.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	err = p.filterFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	outputOpts, err := p.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	commitments, err := limesapi.ListCommitments(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+	if err != nil {
+		return util.WrapError(err, "could not get project commitments")
+	}
+	commitments = p.filterFlags.filter(commitments)
+
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, map[string]any{"commitments": commitments})
+	}
+
+	rep := core.CommitmentsReport{
+		Commitments: commitments,
+		DomainID:    pInfo.DomainID,
+		DomainName:  pInfo.DomainName,
+		ProjectID:   pInfo.ID,
+		ProjectName: pInfo.Name,
+	}
+	if outputOpts.Fmt == core.OutputFormatICS {
+		return writeCommitmentsAsICS(rep)
+	}
+
+	return writeReports(outputOpts, rep)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Project create commitment.
+
+type projectCreateCommitmentCmd struct {
+	*cobra.Command
+
+	projectFlags    projectFlags
+	service         string
+	resource        string
+	az              string
+	amount          string
+	duration        string
+	confirmBy       string
+	notifyOnConfirm bool
+	dryRun          bool
+	outputFmtFlags  resourceOutputFmtFlags
+}
+
+func newProjectCreateCommitmentCmd() *projectCreateCommitmentCmd {
+	projectCreateCommitment := &projectCreateCommitmentCmd{}
+	cmd := &cobra.Command{
+		Use:   "create-commitment [name or ID]",
+		Short: "Create a new commitment for a specific project",
+		Long: `Create a new commitment for a specific project.
+
+Before the commitment is submitted, the request is checked against the
+commitment configuration of the resource (allowed durations and earliest
+confirmation date). For commitments that shall be confirmed immediately,
+limesctl also asks Limes whether there is enough capacity to do so. Use
+'--dry-run' to stop after these checks.
+
+The amount can be given with a unit suffix, e.g. "10 TiB", for resources
+that are measured in bytes.
+
+The project name/ID is optional by default and limesctl will get the project
+from current scope. However, if '--domain' flag is used then either project
+name or ID is required.
+
+This command requires a project-admin token.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    projectCreateCommitment.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectCreateCommitment.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringVar(&projectCreateCommitment.service, "service", "", "service type")
+	cmd.Flags().StringVar(&projectCreateCommitment.resource, "resource", "", "resource name")
+	cmd.Flags().StringVar(&projectCreateCommitment.az, "az", "", "availability zone")
+	cmd.Flags().StringVar(&projectCreateCommitment.amount, "amount", "", `amount, with unit suffix if applicable (e.g. "10 TiB" or "10TiB")`)
+	cmd.Flags().StringVar(&projectCreateCommitment.duration, "duration", "", `duration of the commitment (e.g. "1 year" or "1y")`)
+	cmd.Flags().StringVar(&projectCreateCommitment.confirmBy, "confirm-by", "", "date (YYYY-MM-DD or RFC 3339) by which the commitment shall be confirmed (default: confirm immediately)")
+	cmd.Flags().BoolVar(&projectCreateCommitment.notifyOnConfirm, "notify-on-confirm", false, "send a mail notification when the commitment is confirmed. Requires '--confirm-by'")
+	cmd.Flags().BoolVar(&projectCreateCommitment.dryRun, "dry-run", false, "only check the commitment, do not create it")
+	projectCreateCommitment.outputFmtFlags.AddToCmd(cmd)
+	for _, name := range []string{"service", "resource", "az", "amount", "duration"} {
+		cobra.CheckErr(cmd.MarkFlagRequired(name))
+	}
+
+	projectCreateCommitment.Command = cmd
+	return projectCreateCommitment
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectCreateCommitmentCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := p.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	if p.notifyOnConfirm && p.confirmBy == "" {
+		return errors.New("'--notify-on-confirm' can only be used together with '--confirm-by'")
+	}
+
+	outputOpts, err := p.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	req := limesresources.CommitmentRequest{
+		ServiceType:      limes.ServiceType(p.service),
+		ResourceName:     limesresources.ResourceName(p.resource),
+		AvailabilityZone: limes.AvailabilityZone(p.az),
+		NotifyOnConfirm:  p.notifyOnConfirm,
+	}
+	req.Duration, err = core.ParseCommitmentDuration(p.duration)
+	if err != nil {
+		return err
+	}
+	if p.confirmBy != "" {
+		t, err := parseTimestamp(p.confirmBy)
+		if err != nil {
+			return err
+		}
+		req.ConfirmBy = &limes.UnixEncodedTime{Time: t}
+	}
+
+	// check the request against the resource's commitment configuration
+	resReport, err := getProjectResourceReport(cmd.Context(), pInfo, req.ServiceType, req.ResourceName)
+	if err != nil {
+		return err
+	}
+	req.Amount, err = core.ParseValueInUnit(resReport.Unit, p.amount)
+	if err != nil {
+		return err
+	}
+	err = validateCommitmentRequest(req, resReport)
+	if err != nil {
+		return err
+	}
+
+	if req.ConfirmBy == nil {
+		canConfirm, err := limesapi.CanConfirmCommitment(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, req).Extract()
+		if err != nil {
+			return util.WrapError(err, "could not check whether commitment can be confirmed")
+		}
+		if canConfirm {
+			fmt.Fprintln(os.Stderr, "The commitment can be confirmed immediately.")
+		} else {
+			fmt.Fprintln(os.Stderr, "The commitment cannot be confirmed immediately because there is not enough capacity. It will stay pending until it can be confirmed.")
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "The commitment will be confirmed at %s at the earliest.\n", req.ConfirmBy.Format(time.RFC3339))
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	res := limesapi.CreateCommitment(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, req)
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not create commitment")
+	}
+
+	return writeCommitment(res, outputOpts, pInfo)
+}
+
+// validateCommitmentRequest checks a commitment request against the commitment
+// configuration of the respective resource.
+func validateCommitmentRequest(req limesresources.CommitmentRequest, resReport *limesresources.ProjectResourceReport) error {
+	fullResourceName := fmt.Sprintf("%s/%s", req.ServiceType, req.ResourceName)
+	cfg := resReport.CommitmentConfig
+	if cfg == nil {
+		return fmt.Errorf("%s does not accept commitments", fullResourceName)
+	}
+
+	if err := validateCommitmentDuration(fullResourceName, cfg, req.Duration); err != nil {
+		return err
+	}
+
+	if len(resReport.PerAZ) > 0 {
+		if _, exists := resReport.PerAZ[req.AvailabilityZone]; !exists {
+			return fmt.Errorf("%q is not a valid availability zone for %s", req.AvailabilityZone, fullResourceName)
+		}
+	}
+
+	if req.Amount == 0 {
+		return errors.New("commitment amount must be greater than zero")
+	}
+
+	if cfg.MinConfirmBy != nil {
+		minConfirmBy := cfg.MinConfirmBy.Format(time.RFC3339)
+		if req.ConfirmBy == nil {
+			return fmt.Errorf("commitments for %s cannot be confirmed immediately, use '--confirm-by' with a date no earlier than %s",
+				fullResourceName, minConfirmBy)
+		}
+		if req.ConfirmBy.Before(cfg.MinConfirmBy.Time) {
+			return fmt.Errorf("commitments for %s cannot be confirmed before %s", fullResourceName, minConfirmBy)
+		}
+	}
+
+	return nil
+}
+
+// validateCommitmentDuration checks that the given duration is one of the
+// durations accepted by the resource.
+func validateCommitmentDuration(fullResourceName string, cfg *limesresources.CommitmentConfiguration, duration limesresources.CommitmentDuration) error {
+	if slices.Contains(cfg.Durations, duration) {
+		return nil
+	}
+	durations := make([]string, len(cfg.Durations))
+	for idx, d := range cfg.Durations {
+		durations[idx] = fmt.Sprintf("%q", d.String())
+	}
+	return fmt.Errorf("%q is not an acceptable duration for %s, valid durations are: %s",
+		duration.String(), fullResourceName, strings.Join(durations, ", "))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Project delete commitment.
+
+type projectDeleteCommitmentCmd struct {
+	*cobra.Command
+
+	projectFlags projectScopeFlags
+	yes          bool
+}
+
+func newProjectDeleteCommitmentCmd() *projectDeleteCommitmentCmd {
+	projectDeleteCommitment := &projectDeleteCommitmentCmd{}
+	cmd := &cobra.Command{
+		Use:   "delete-commitment <uuid>...",
+		Short: "Delete commitments of a specific project",
+		Long: `Delete one or more commitments of a specific project.
+
+Limes only allows deleting commitments under certain conditions (e.g. shortly
+after their creation, or while they are not confirmed yet). Commitments that
+cannot be deleted are reported and skipped. A summary of the commitments that
+will be deleted is shown and needs to be confirmed interactively unless
+'--yes' is given. The command exits with a non-zero status if any commitment
+could not be deleted.
+
+The project is taken from the current scope unless '--project' is given.
+
+This command requires a project-admin token.`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    projectDeleteCommitment.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectDeleteCommitment.projectFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVarP(&projectDeleteCommitment.yes, "yes", "y", false, "do not ask for confirmation")
+
+	projectDeleteCommitment.Command = cmd
+	return projectDeleteCommitment
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectDeleteCommitmentCmd) Run(cmd *cobra.Command, args []string) error {
+	// ignore duplicate arguments, so that each commitment is counted only once
+	var uuids []string
+	for _, uuid := range args {
+		if !slices.Contains(uuids, uuid) {
+			uuids = append(uuids, uuid)
+		}
+	}
+
+	pInfo, err := p.projectFlags.findProject(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	allCommitments, err := limesapi.ListCommitments(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID).ExtractCommitments()
+	if err != nil {
+		return util.WrapError(err, "could not get project commitments")
+	}
+	commitmentsByUUID := make(map[string]limesresources.Commitment, len(allCommitments))
+	for _, c := range allCommitments {
+		commitmentsByUUID[c.UUID] = c
+	}
+
+	// refuse to delete commitments that Limes would not allow us to delete
+	var (
+		toDelete    []limesresources.Commitment
+		failedCount int
+	)
+	for _, uuid := range uuids {
+		c, exists := commitmentsByUUID[uuid]
+		switch {
+		case !exists:
+			fmt.Fprintf(os.Stderr, "ERROR: commitment %s does not exist in project %s\n", uuid, pInfo.ID)
+			failedCount++
+		case !c.CanBeDeleted:
+			fmt.Fprintf(os.Stderr, "ERROR: commitment %s cannot be deleted: %s\n", uuid, reasonForUndeletableCommitment(c))
+			failedCount++
+		default:
+			toDelete = append(toDelete, c)
+		}
+	}
+	if len(toDelete) == 0 {
+		return errors.New("no commitments to delete")
+	}
+
+	fmt.Println("The following commitments will be deleted:")
+	err = writeReports(&core.OutputOpts{Fmt: core.OutputFormatTable, CSVRecFmt: core.CSVRecordFormatDefault, Humanize: true},
+		core.CommitmentsReport{
+			Commitments: toDelete,
+			DomainID:    pInfo.DomainID,
+			DomainName:  pInfo.DomainName,
+			ProjectID:   pInfo.ID,
+			ProjectName: pInfo.Name,
+		})
+	if err != nil {
+		return err
+	}
+	if !p.yes && !askForConfirmation(fmt.Sprintf("Delete %d commitment(s)?", len(toDelete))) {
+		return errors.New("aborted")
+	}
+
+	for _, c := range toDelete {
+		err := limesapi.DeleteCommitment(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, c.UUID).ExtractErr()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: could not delete commitment %s: %s\n", c.UUID, err.Error())
+			failedCount++
+			continue
+		}
+		fmt.Printf("deleted commitment %s\n", c.UUID)
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("could not delete %d of %d commitment(s)", failedCount, len(uuids))
+	}
+	return nil
+}
+
+// reasonForUndeletableCommitment explains why Limes does not allow deleting
+// the given commitment.
+func reasonForUndeletableCommitment(c limesresources.Commitment) string {
+	switch c.Status {
+	case liquid.CommitmentStatusExpired, liquid.CommitmentStatusSuperseded:
+		return fmt.Sprintf("the commitment is %s", c.Status)
+	case liquid.CommitmentStatusConfirmed, liquid.CommitmentStatusGuaranteed:
+		return fmt.Sprintf("the commitment is %s and was created at %s; only commitments created less than 24 hours ago can be deleted by project admins",
+			c.Status, c.CreatedAt.Format(time.RFC3339))
+	default:
+		return "you do not have permission to delete this commitment"
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Project show.
 
 type projectShowCmd struct {
 	*cobra.Command
 
-	projectFlags   projectFlags
-	filterFlags    resourceFilterFlags
-	outputFmtFlags resourceOutputFmtFlags
+	projectFlags      projectFlags
+	filterFlags       resourceFilterFlags
+	driftOnly         bool
+	subresourcesFlags subdivisionFlags
+	outputFmtFlags    resourceReportOutputFmtFlags
 }
 
 func newProjectShowCmd() *projectShowCmd {
@@ -233,6 +732,8 @@ This command requires a project member permissions.`,
 	doNotSortFlags(cmd)
 	projectShow.projectFlags.AddToCmd(cmd)
 	projectShow.filterFlags.AddToCmd(cmd)
+	cmd.Flags().BoolVar(&projectShow.driftOnly, "drift-only", false, "only show resources whose backend quota differs from the quota in Limes")
+	projectShow.subresourcesFlags.AddToCmd(cmd, "subresources")
 	projectShow.outputFmtFlags.AddToCmd(cmd)
 
 	projectShow.Command = cmd
@@ -254,6 +755,11 @@ func (p *projectShowCmd) Run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filters, err := p.subresourcesFlags.validate("subresources", outputOpts)
+	if err != nil {
+		return err
+	}
+	requestPerAZReportsIfNeeded(outputOpts)
 
 	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
 	if err != nil {
@@ -261,6 +767,7 @@ func (p *projectShowCmd) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	res := projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{
+		Detail:    p.subresourcesFlags.enabled,
 		Areas:     p.filterFlags.areas,
 		Services:  util.CastStringsTo[limes.ServiceType](p.filterFlags.services),
 		Resources: util.CastStringsTo[limesresources.ResourceName](p.filterFlags.resources),
@@ -269,8 +776,8 @@ func (p *projectShowCmd) Run(cmd *cobra.Command, args []string) error {
 		return util.WrapError(res.Err, "could not get project report")
 	}
 
-	if p.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) && !p.driftOnly {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesRep, err := res.Extract()
@@ -278,6 +785,21 @@ func (p *projectShowCmd) Run(cmd *cobra.Command, args []string) error {
 		return util.WrapError(err, "could not extract project report")
 	}
 
+	if p.driftOnly {
+		core.RemoveResourcesWithoutDrift(limesRep)
+		if isStructuredFormat(outputOpts.Fmt) {
+			return writeStructured(outputOpts, map[string]any{"project": limesRep})
+		}
+	}
+
+	if p.subresourcesFlags.enabled {
+		rep, err := core.NewSubresourcesReport(pInfo.DomainID, pInfo.DomainName, limesRep, filters)
+		if err != nil {
+			return err
+		}
+		return writeReports(outputOpts, rep)
+	}
+
 	return writeReports(outputOpts, core.ProjectResourcesReport{
 		ProjectReport: limesRep,
 		DomainID:      pInfo.DomainID,
@@ -352,8 +874,8 @@ func (p *projectShowRatesCmd) Run(cmd *cobra.Command, args []string) error {
 		return util.WrapError(res.Err, "could not get project report")
 	}
 
-	if p.outputFmtFlags.format == core.OutputFormatJSON {
-		return writeJSON(res.Body)
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, res.Body)
 	}
 
 	limesRep, err := res.Extract()
@@ -426,3 +948,490 @@ func (p *projectSyncCmd) Run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// Project set max quota.
+
+type projectSetMaxQuotaCmd struct {
+	*cobra.Command
+
+	projectFlags projectFlags
+	quotas       []string
+	unset        []string
+	dryRun       bool
+}
+
+func newProjectSetMaxQuotaCmd() *projectSetMaxQuotaCmd {
+	projectSetMaxQuota := &projectSetMaxQuotaCmd{}
+	cmd := &cobra.Command{
+		Use:   "set-max-quota [name or ID]",
+		Short: "Set or remove the max_quota constraint of resources of a specific project",
+		Long: `Set or remove the max_quota constraint of resources of a specific project.
+
+Values are given as "service/resource=value". Values for resources with a unit
+require a unit suffix, e.g. "object-store/capacity=10TiB". Use
+'--unset service/resource' to remove a constraint. Only resources that track
+quota can be constrained.
+
+The changes are shown as a before/after comparison before they are applied.
+With '--dry-run', limesctl stops after showing the comparison.
+
+The project name/ID is optional by default and limesctl will get the project
+from current scope. However, if '--domain' flag is used then either project
+name or ID is required.
+
+This command requires a project-admin token.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    projectSetMaxQuota.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectSetMaxQuota.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringSliceVar(&projectSetMaxQuota.quotas, "quotas", nil, "max quotas as service/resource=value (comma separated list)")
+	cmd.Flags().StringSliceVar(&projectSetMaxQuota.unset, "unset", nil, "remove the max quota of these resources, given as service/resource (comma separated list)")
+	cmd.Flags().BoolVar(&projectSetMaxQuota.dryRun, "dry-run", false, "only show the changes")
+
+	projectSetMaxQuota.Command = cmd
+	return projectSetMaxQuota
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectSetMaxQuotaCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := p.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	if len(p.quotas) == 0 && len(p.unset) == 0 {
+		return errors.New("at least one of '--quotas' and '--unset' is required")
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+	report, err := projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{}).Extract()
+	if err != nil {
+		return util.WrapError(err, "could not get project report")
+	}
+
+	// collect the requested changes
+	var changes []core.MaxQuotaChange
+	// an empty valueStr removes the constraint
+	addChange := func(ref, valueStr string) error {
+		srvType, resName, err := parseResourceRef(ref)
+		if err != nil {
+			return err
+		}
+		resReport, err := findResourceReport(report, srvType, resName)
+		if err != nil {
+			return err
+		}
+		if resReport.Quota == nil {
+			return fmt.Errorf("%s/%s does not track quota", srvType, resName)
+		}
+		if slices.ContainsFunc(changes, func(c core.MaxQuotaChange) bool {
+			return c.ServiceType == srvType && c.ResourceName == resName
+		}) {
+			return fmt.Errorf("%s/%s is given more than once", srvType, resName)
+		}
+		var after *uint64
+		if valueStr != "" {
+			value, err := core.ParseValueInUnit(resReport.Unit, valueStr)
+			if err != nil {
+				return util.WrapError(err, fmt.Sprintf("invalid max quota for %s/%s", srvType, resName))
+			}
+			after = &value
+		}
+		changes = append(changes, core.MaxQuotaChange{
+			ServiceType:  srvType,
+			ResourceName: resName,
+			Unit:         resReport.Unit,
+			Before:       resReport.MaxQuota,
+			After:        after,
+		})
+		return nil
+	}
+	for _, assignment := range p.quotas {
+		ref, valueStr, found := strings.Cut(assignment, "=")
+		if !found || valueStr == "" {
+			return fmt.Errorf("invalid max quota %q: expected format service/resource=value", assignment)
+		}
+		if err := addChange(ref, valueStr); err != nil {
+			return err
+		}
+	}
+	for _, ref := range p.unset {
+		if err := addChange(ref, ""); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Changes to the max quota of project %s:\n", pInfo.Name)
+	err = writeReports(&core.OutputOpts{Fmt: core.OutputFormatTable, Humanize: true}, core.MaxQuotaChangesReport{Changes: changes})
+	if err != nil {
+		return err
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	// apply
+	opts := buildSetMaxQuotaOpts(changes)
+	err = limesapi.SetMaxQuota(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, opts).ExtractErr()
+	if err != nil {
+		return util.WrapError(err, "could not set max quota")
+	}
+	fmt.Println("Max quota updated.")
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Project set autogrowth.
+
+type projectSetAutogrowthCmd struct {
+	*cobra.Command
+
+	projectFlags   projectFlags
+	resources      []string
+	forbid         bool
+	allow          bool
+	dryRun         bool
+	outputFmtFlags resourceOutputFmtFlags
+}
+
+func newProjectSetAutogrowthCmd() *projectSetAutogrowthCmd {
+	projectSetAutogrowth := &projectSetAutogrowthCmd{}
+	cmd := &cobra.Command{
+		Use:   "set-autogrowth [name or ID]",
+		Short: "Forbid or allow quota autogrowth for resources of a specific project",
+		Long: `Forbid or allow quota autogrowth for resources of a specific project.
+
+While autogrowth is forbidden, the quota of a resource does not grow beyond its
+current usage. This is only possible for resources that use the autogrow quota
+distribution model. Resources are given as "service/resource".
+
+The changes are shown as a before/after comparison before they are applied
+(unless a machine-readable output format is selected). With '--dry-run',
+limesctl stops after showing the comparison. Otherwise, the resulting quota and
+usable quota of the resources are shown after the change.
+
+The project name/ID is optional by default and limesctl will get the project
+from current scope. However, if '--domain' flag is used then either project
+name or ID is required.
+
+This command requires a project-admin token.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesResources,
+		RunE:    projectSetAutogrowth.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectSetAutogrowth.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringSliceVar(&projectSetAutogrowth.resources, "resources", nil, "resources as service/resource (comma separated list)")
+	cmd.Flags().BoolVar(&projectSetAutogrowth.forbid, "forbid", false, "forbid autogrowth")
+	cmd.Flags().BoolVar(&projectSetAutogrowth.allow, "allow", false, "allow autogrowth")
+	cmd.Flags().BoolVar(&projectSetAutogrowth.dryRun, "dry-run", false, "only show the changes")
+	projectSetAutogrowth.outputFmtFlags.AddToCmd(cmd)
+	cobra.CheckErr(cmd.MarkFlagRequired("resources"))
+	cmd.MarkFlagsOneRequired("forbid", "allow")
+	cmd.MarkFlagsMutuallyExclusive("forbid", "allow")
+
+	projectSetAutogrowth.Command = cmd
+	return projectSetAutogrowth
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectSetAutogrowthCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := p.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	outputOpts, err := p.outputFmtFlags.validate()
+	if err != nil {
+		return err
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+	report, err := projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{}).Extract()
+	if err != nil {
+		return util.WrapError(err, "could not get project report")
+	}
+
+	var changes []core.AutogrowthChange
+	refs := make(map[limes.ServiceType][]limesresources.ResourceName)
+	for _, ref := range p.resources {
+		srvType, resName, err := parseResourceRef(ref)
+		if err != nil {
+			return err
+		}
+		resReport, err := findResourceReport(report, srvType, resName)
+		if err != nil {
+			return err
+		}
+		if resReport.QuotaDistributionModel != limesresources.AutogrowQuotaDistribution {
+			return fmt.Errorf("%s/%s does not use the %s quota distribution model", srvType, resName, limesresources.AutogrowQuotaDistribution)
+		}
+		if slices.Contains(refs[srvType], resName) {
+			return fmt.Errorf("%s/%s is given more than once", srvType, resName)
+		}
+		changes = append(changes, core.AutogrowthChange{
+			ServiceType:  srvType,
+			ResourceName: resName,
+			Before:       resReport.ForbidAutogrowth,
+			After:        p.forbid,
+		})
+		refs[srvType] = append(refs[srvType], resName)
+	}
+
+	// the comparison would interfere with machine-readable output, so it is
+	// only shown for tables unless it is all that was asked for
+	if p.dryRun || outputOpts.Fmt == "" || outputOpts.Fmt == core.OutputFormatTable {
+		fmt.Printf("Changes to the autogrowth settings of project %s:\n", pInfo.Name)
+		err = writeReports(&core.OutputOpts{Fmt: core.OutputFormatTable}, core.AutogrowthChangesReport{Changes: changes})
+		if err != nil {
+			return err
+		}
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	opts := buildSetForbidAutogrowthOpts(changes)
+	err = limesapi.SetForbidAutogrowth(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, opts).ExtractErr()
+	if err != nil {
+		return util.WrapError(err, "could not update autogrowth settings")
+	}
+
+	// show the resulting quotas of the affected resources
+	res := projects.Get(cmd.Context(), limesResourcesClient, pInfo.DomainID, pInfo.ID, projects.GetOpts{
+		Services: slices.Collect(maps.Keys(refs)),
+	})
+	if res.Err != nil {
+		return util.WrapError(res.Err, "could not get project report")
+	}
+	report, err = res.Extract()
+	if err != nil {
+		return util.WrapError(err, "could not extract project report")
+	}
+	for srvType, srvReport := range report.Services {
+		maps.DeleteFunc(srvReport.Resources, func(resName limesresources.ResourceName, _ *limesresources.ProjectResourceReport) bool {
+			return !slices.Contains(refs[srvType], resName)
+		})
+	}
+
+	if isStructuredFormat(outputOpts.Fmt) {
+		return writeStructured(outputOpts, map[string]any{"project": report})
+	}
+
+	return writeReports(outputOpts, core.ProjectAutogrowthReport{ProjectReport: report})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Project set rates.
+
+type projectSetRatesCmd struct {
+	*cobra.Command
+
+	projectFlags projectFlags
+	rates        []string
+	reset        []string
+	dryRun       bool
+}
+
+func newProjectSetRatesCmd() *projectSetRatesCmd {
+	projectSetRates := &projectSetRatesCmd{}
+	cmd := &cobra.Command{
+		Use:   "set-rates [name or ID]",
+		Short: "Set the rate limits of a specific project",
+		Long: `Set the rate limits of a specific project.
+
+Rate limits are given as "service/rate=limit/window", e.g.
+"object-store/objects:create=1000/1m". Windows are given as a number with a
+unit of ms, s, m or h. Use '--reset service/rate' to restore the default limit
+and window of a rate.
+
+The changes are shown next to the current and the default limits before they
+are applied. With '--dry-run', limesctl stops after showing the comparison.
+
+The project name/ID is optional by default and limesctl will get the project
+from current scope. However, if '--domain' flag is used then either project
+name or ID is required.
+
+This command requires a cloud-admin token.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: authWithLimesRates,
+		RunE:    projectSetRates.Run,
+	}
+
+	// Flags
+	doNotSortFlags(cmd)
+	projectSetRates.projectFlags.AddToCmd(cmd)
+	cmd.Flags().StringArrayVar(&projectSetRates.rates, "rate", nil, "rate limit as service/rate=limit/window (can be given multiple times)")
+	cmd.Flags().StringSliceVar(&projectSetRates.reset, "reset", nil, "restore the default limits of these rates, given as service/rate (comma separated list)")
+	cmd.Flags().BoolVar(&projectSetRates.dryRun, "dry-run", false, "only show the changes")
+
+	projectSetRates.Command = cmd
+	return projectSetRates
+}
+
+// Run is called by Cobra when this command is executed.
+func (p *projectSetRatesCmd) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := ""
+	if len(args) > 0 {
+		nameOrID = args[0]
+	}
+	err := p.projectFlags.validateWithNameID(nameOrID)
+	if err != nil {
+		return err
+	}
+	if len(p.rates) == 0 && len(p.reset) == 0 {
+		return errors.New("at least one of '--rate' and '--reset' is required")
+	}
+
+	pInfo, err := auth.FindProject(cmd.Context(), identityClient, p.projectFlags.DomainNameOrID, nameOrID)
+	if err != nil {
+		return err
+	}
+	report, err := ratesProjects.Get(cmd.Context(), limesRatesClient, pInfo.DomainID, pInfo.ID, ratesProjects.ReadOpts{}).Extract()
+	if err != nil {
+		return util.WrapError(err, "could not get project report")
+	}
+
+	// collect the requested changes
+	var changes []core.RateLimitChange
+	// a nil window restores the default limit
+	addChange := func(ref string, limit uint64, window *limesrates.Window) error {
+		srvType, rateName, err := parseResourceRef(ref)
+		if err != nil {
+			return err
+		}
+		var rateReport *limesrates.ProjectRateReport
+		if srvReport := report.Services[srvType]; srvReport != nil {
+			rateReport = srvReport.Rates[limesrates.RateName(rateName)]
+		}
+		if rateReport == nil {
+			return fmt.Errorf("%q is not a valid rate", fmt.Sprintf("%s/%s", srvType, rateName))
+		}
+		if slices.ContainsFunc(changes, func(c core.RateLimitChange) bool {
+			return c.ServiceType == srvType && c.RateName == rateReport.Name
+		}) {
+			return fmt.Errorf("%s/%s is given more than once", srvType, rateName)
+		}
+		if window == nil {
+			if rateReport.DefaultWindow == nil {
+				return fmt.Errorf("%s/%s does not have a default rate limit", srvType, rateName)
+			}
+			limit, window = rateReport.DefaultLimit, rateReport.DefaultWindow
+		}
+		changes = append(changes, core.RateLimitChange{
+			ServiceType:   srvType,
+			RateName:      rateReport.Name,
+			Limit:         rateReport.Limit,
+			Window:        rateReport.Window,
+			NewLimit:      limit,
+			NewWindow:     *window,
+			DefaultLimit:  rateReport.DefaultLimit,
+			DefaultWindow: rateReport.DefaultWindow,
+		})
+		return nil
+	}
+	for _, assignment := range p.rates {
+		ref, valueStr, found := strings.Cut(assignment, "=")
+		idx := strings.LastIndex(valueStr, "/")
+		if !found || idx < 0 {
+			return fmt.Errorf("invalid rate limit %q: expected format service/rate=limit/window", assignment)
+		}
+		limit, err := strconv.ParseUint(valueStr[:idx], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid limit in %q: %w", assignment, err)
+		}
+		window, err := limesrates.ParseWindow(valueStr[idx+1:])
+		if err != nil {
+			return fmt.Errorf("invalid window in %q: %w", assignment, err)
+		}
+		if err := addChange(ref, limit, &window); err != nil {
+			return err
+		}
+	}
+	for _, ref := range p.reset {
+		if err := addChange(ref, 0, nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Changes to the rate limits of project %s:\n", pInfo.Name)
+	err = writeReports(&core.OutputOpts{Fmt: core.OutputFormatTable}, core.RateLimitChangesReport{Changes: changes})
+	if err != nil {
+		return err
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	// apply
+	req := make(limesrates.RateRequest)
+	for _, c := range changes {
+		if req[c.ServiceType] == nil {
+			req[c.ServiceType] = make(limesrates.ServiceRequest)
+		}
+		req[c.ServiceType][c.RateName] = limesrates.RateLimitRequest{Limit: c.NewLimit, Window: c.NewWindow}
+	}
+	err = limesapi.SetProjectRates(cmd.Context(), limesRatesClient, pInfo.DomainID, pInfo.ID, req).ExtractErr()
+	if err != nil {
+		return util.WrapError(err, "could not set rate limits")
+	}
+	fmt.Println("Rate limits updated.")
+
+	return nil
+}
+
+// buildSetMaxQuotaOpts converts max_quota changes into a request body for limesapi.SetMaxQuota.
+func buildSetMaxQuotaOpts(changes []core.MaxQuotaChange) limesapi.SetMaxQuotaOpts {
+	var opts limesapi.SetMaxQuotaOpts
+	for _, c := range changes {
+		idx := slices.IndexFunc(opts.Services, func(s limesapi.MaxQuotaService) bool { return s.Type == c.ServiceType })
+		if idx < 0 {
+			opts.Services = append(opts.Services, limesapi.MaxQuotaService{Type: c.ServiceType})
+			idx = len(opts.Services) - 1
+		}
+		opts.Services[idx].Resources = append(opts.Services[idx].Resources, limesapi.MaxQuotaResource{
+			Name:     c.ResourceName,
+			MaxQuota: c.After,
+			Unit:     c.Unit,
+		})
+	}
+	return opts
+}
+
+// buildSetForbidAutogrowthOpts converts forbid_autogrowth changes into a
+// request body for limesapi.SetForbidAutogrowth.
+func buildSetForbidAutogrowthOpts(changes []core.AutogrowthChange) limesapi.SetForbidAutogrowthOpts {
+	var opts limesapi.SetForbidAutogrowthOpts
+	for _, c := range changes {
+		idx := slices.IndexFunc(opts.Services, func(s limesapi.ForbidAutogrowthService) bool { return s.Type == c.ServiceType })
+		if idx < 0 {
+			opts.Services = append(opts.Services, limesapi.ForbidAutogrowthService{Type: c.ServiceType})
+			idx = len(opts.Services) - 1
+		}
+		opts.Services[idx].Resources = append(opts.Services[idx].Resources, limesapi.ForbidAutogrowthResource{
+			Name:             c.ResourceName,
+			ForbidAutogrowth: c.After,
+		})
+	}
+	return opts
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -32,10 +33,31 @@ type VersionInfo struct {
 func Execute(ctx context.Context, v *VersionInfo) {
 	if err := newRootCmd(v).ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
+		var ecErr exitCodeError
+		if errors.As(err, &ecErr) {
+			os.Exit(ecErr.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitCodeError is an error that makes limesctl exit with a specific status
+// code instead of the default one.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+// Error implements the builtin/error interface.
+func (e exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap implements the interface used by errors.Is and errors.As.
+func (e exitCodeError) Unwrap() error {
+	return e.err
+}
+
 // Global flags.
 var (
 	debug bool
@@ -85,8 +107,11 @@ func newRootCmd(v *VersionInfo) *cobra.Command {
 	cmd.AddCommand(newClusterCmd())
 	cmd.AddCommand(newDomainCmd())
 	cmd.AddCommand(newProjectCmd())
+	cmd.AddCommand(newCommitmentCmd())
 	cmd.AddCommand(newOpsCmd())
 	cmd.AddCommand(newLiquidCmd())
+	cmd.AddCommand(newPlanCmd().Command)
+	cmd.AddCommand(newApplyCmd().Command)
 
 	return cmd
 }
@@ -4,9 +4,16 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
 
 	"github.com/sapcc/limesctl/v3/internal/core"
 	"github.com/sapcc/limesctl/v3/internal/util"
@@ -25,13 +32,129 @@ func writeJSON(d any) error {
 	return nil
 }
 
+// isStructuredFormat returns whether data is printed with the same structure as
+// in the Limes API, i.e. in JSON or YAML format or through a template.
+func isStructuredFormat(f core.OutputFormat) bool {
+	switch kind, _ := core.SplitOutputFormat(f); kind {
+	case core.OutputFormatJSON, core.OutputFormatYAML,
+		core.OutputFormatTemplate, core.OutputFormatTemplateFile, core.OutputFormatJSONPath:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeStructured writes d to os.Stdout in JSON or YAML format or through a
+// template, as requested by opts.
+func writeStructured(opts *core.OutputOpts, d any) error {
+	if opts.Fmt == core.OutputFormatJSON {
+		return writeJSON(d)
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return util.WrapError(err, "could not marshal JSON")
+	}
+	if opts.Fmt != core.OutputFormatYAML {
+		return core.WriteWithTemplate(os.Stdout, opts.Fmt, b)
+	}
+	b, err = core.ConvertJSONToYAML(b, opts.Humanize)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stdout.Write(b); err != nil {
+		return util.WrapError(err, "could not write YAML data")
+	}
+
+	return nil
+}
+
 func writeReports(opts *core.OutputOpts, reports ...core.LimesReportRenderer) error {
-	d := core.RenderReports(opts, reports...)
-	var err error
-	if opts.Fmt == core.OutputFormatCSV {
-		err = d.Write(os.Stdout)
-	} else {
-		d.WriteAsTable()
-	}
-	return err
+	switch opts.Fmt {
+	case core.OutputFormatJSONRows, core.OutputFormatNDJSON:
+		return core.WriteReportsAsJSONRows(os.Stdout, opts, opts.Fmt == core.OutputFormatNDJSON, reports...)
+	case core.OutputFormatCSV:
+		return core.RenderReports(opts, reports...).Write(os.Stdout)
+	default:
+		core.RenderReports(opts, reports...).WriteAsTable()
+		return nil
+	}
+}
+
+// requestPerAZReportsIfNeeded makes Limes include the per-AZ breakdown of
+// resources in its reports if the output requires it. This breakdown is part
+// of the v2 API preview, and it is also where the commitment amounts are.
+func requestPerAZReportsIfNeeded(opts *core.OutputOpts) {
+	if !opts.PerAZ && !opts.Commitments && !opts.CommitmentsByDuration {
+		return
+	}
+	if limesResourcesClient.MoreHeaders == nil {
+		limesResourcesClient.MoreHeaders = make(map[string]string)
+	}
+	limesResourcesClient.MoreHeaders["X-Limes-V2-API-Preview"] = "per-az"
+}
+
+// writeCommitmentsAsICS writes commitment listings to os.Stdout in iCalendar format.
+func writeCommitmentsAsICS(reps ...core.CommitmentsReport) error {
+	return core.WriteCommitmentsAsICS(os.Stdout, reps...)
+}
+
+// parseResourceRef parses a resource reference of the form "service/resource".
+func parseResourceRef(input string) (limes.ServiceType, limesresources.ResourceName, error) {
+	srvType, resName, found := strings.Cut(input, "/")
+	if !found || srvType == "" || resName == "" {
+		return "", "", fmt.Errorf("invalid resource %q: expected format service/resource", input)
+	}
+	return limes.ServiceType(srvType), limesresources.ResourceName(resName), nil
+}
+
+// parseTimestamp parses a user-supplied point in time. Both RFC 3339
+// timestamps and plain dates (interpreted as midnight UTC) are accepted.
+func parseTimestamp(input string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		t, err := time.Parse(layout, input)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("could not parse timestamp %q: expected format YYYY-MM-DD or RFC 3339", input)
+}
+
+// askForConfirmation asks the user a yes/no question on stdin. Anything other
+// than an explicit "y" or "yes" counts as a no.
+func askForConfirmation(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseTimeWindow parses a time window like "30d" or "12h". In addition to
+// the units understood by time.ParseDuration, days ("d") and weeks ("w") are
+// accepted.
+func parseTimeWindow(input string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		numStr, found := strings.CutSuffix(input, suffix)
+		if !found {
+			continue
+		}
+		num, err := strconv.ParseUint(numStr, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse time window %q", input)
+		}
+		return time.Duration(num) * unit, nil
+	}
+	d, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse time window %q: expected a value like 30d, 2w or 12h", input)
+	}
+	return d, nil
 }
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// ProjectAutogrowthReport is a wrapper for limesresources.ProjectReport that
+// shows the autogrowth settings and the resulting quotas of the project's
+// resources. Resources that do not track quota are skipped.
+type ProjectAutogrowthReport struct {
+	*limesresources.ProjectReport
+}
+
+var csvHeaderProjectAutogrowth = []string{
+	csvHeaderService, csvHeaderResource, csvHeaderDistribution, csvHeaderForbidAutogrow,
+	csvHeaderQuota, csvHeaderUsableQuota, csvHeaderUsage, csvHeaderUnit,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (p ProjectAutogrowthReport) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderProjectAutogrowth
+}
+
+// Render implements the LimesReportRenderer interface.
+func (p ProjectAutogrowthReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize service types with ordered keys
+	types := make([]limes.ServiceType, 0, len(p.Services))
+	for typeStr := range p.Services {
+		types = append(types, typeStr)
+	}
+	slices.Sort(types)
+
+	for _, srv := range types {
+		// Serialize resource names with ordered keys
+		names := make([]limesresources.ResourceName, 0, len(p.Services[srv].Resources))
+		for nameStr := range p.Services[srv].Resources {
+			names = append(names, nameStr)
+		}
+		slices.Sort(names)
+
+		for _, res := range names {
+			pSrvRes := p.Services[srv].Resources[res]
+			if pSrvRes.Quota == nil {
+				continue
+			}
+
+			unit, formatter := pSrvRes.Unit, DefaultValueFormatter
+			if opts.Humanize {
+				unit, formatter = PickHumanizedValueFormatter(unit, []uint64{
+					zeroIfNil(pSrvRes.Quota), zeroIfNil(pSrvRes.UsableQuota), pSrvRes.Usage,
+				})
+			}
+
+			model := pSrvRes.QuotaDistributionModel
+			if model == "" {
+				model = limesresources.HierarchicalQuotaDistribution
+			}
+
+			records = append(records, []string{
+				string(srv), string(res), string(model), strconv.FormatBool(pSrvRes.ForbidAutogrowth),
+				emptyStrIfNil(pSrvRes.Quota, formatter), emptyStrIfNil(pSrvRes.UsableQuota, formatter),
+				formatter(pSrvRes.Usage), unit.String(),
+			})
+		}
+	}
+
+	return records
+}
+
+// AutogrowthChange describes a change of the forbid_autogrowth setting of a
+// single project resource.
+type AutogrowthChange struct {
+	ServiceType  limes.ServiceType
+	ResourceName limesresources.ResourceName
+	Before       bool
+	After        bool
+}
+
+// AutogrowthChangesReport renders a before/after comparison of the
+// forbid_autogrowth settings of a single project.
+type AutogrowthChangesReport struct {
+	Changes []AutogrowthChange
+}
+
+var csvHeaderAutogrowthChanges = []string{
+	csvHeaderService, csvHeaderResource, csvHeaderForbidAutogrowBefore, csvHeaderForbidAutogrowAfter,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (a AutogrowthChangesReport) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderAutogrowthChanges
+}
+
+// Render implements the LimesReportRenderer interface.
+func (a AutogrowthChangesReport) render(_ *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize changes in a stable order
+	changes := slices.Clone(a.Changes)
+	slices.SortFunc(changes, func(lhs, rhs AutogrowthChange) int {
+		return cmp.Or(
+			cmp.Compare(lhs.ServiceType, rhs.ServiceType),
+			cmp.Compare(lhs.ResourceName, rhs.ResourceName),
+		)
+	})
+
+	for _, c := range changes {
+		records = append(records, []string{
+			string(c.ServiceType), string(c.ResourceName),
+			strconv.FormatBool(c.Before), strconv.FormatBool(c.After),
+		})
+	}
+
+	return records
+}
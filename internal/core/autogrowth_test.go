@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestProjectAutogrowthReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	// emulate a resource with autogrowth that was frozen
+	res := data.Project.Services["shared"].Resources["things"]
+	res.QuotaDistributionModel = limesresources.AutogrowQuotaDistribution
+	res.ForbidAutogrowth = true
+
+	var actual bytes.Buffer
+	err = RenderReports(&OutputOpts{}, ProjectAutogrowthReport{ProjectReport: &data.Project}).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-dresden-autogrowth.csv", actual.Bytes())
+}
+
+func TestAutogrowthChangesReportRender(t *testing.T) {
+	rep := AutogrowthChangesReport{
+		Changes: []AutogrowthChange{
+			{ServiceType: "shared", ResourceName: "things", Before: false, After: true},
+			{ServiceType: "shared", ResourceName: "capacity", Before: true, After: true},
+			{ServiceType: "first", ResourceName: "capacity", Before: true, After: false},
+		},
+	}
+
+	var actual bytes.Buffer
+	err := RenderReports(&OutputOpts{}, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "autogrowth-changes.csv", actual.Bytes())
+}
@@ -4,6 +4,7 @@
 package core
 
 import (
+	"maps"
 	"slices"
 
 	"github.com/sapcc/go-api-declarations/limes"
@@ -27,16 +28,39 @@ var csvHeaderClusterDefault = []string{
 	csvHeaderUnit,
 }
 
+var csvHeaderClusterPerAZLong = []string{
+	csvHeaderClusterID, csvHeaderArea, csvHeaderService, csvHeaderCategory, csvHeaderResource, csvHeaderAZ,
+	csvHeaderCapacity, csvHeaderUsage, csvHeaderProjectsUsage, csvHeaderPhysicalUsage,
+	csvHeaderUnusedCommitments, csvHeaderUncommittedUsage,
+	csvHeaderUnit, csvHeaderScrapedAt,
+}
+
+var csvHeaderClusterPerAZDefault = []string{
+	csvHeaderClusterID, csvHeaderService, csvHeaderResource, csvHeaderAZ,
+	csvHeaderCapacity, csvHeaderUsage, csvHeaderProjectsUsage,
+	csvHeaderUnusedCommitments, csvHeaderUncommittedUsage,
+	csvHeaderUnit,
+}
+
 // GetHeaderRow implements the LimesReportRenderer interface.
 func (c ClusterReport) getHeaderRow(opts *OutputOpts) []string {
+	if opts.PerAZ {
+		if opts.CSVRecFmt == CSVRecordFormatLong {
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderClusterPerAZLong), csvHeaderClusterPerAZUtilization)
+		}
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderClusterPerAZDefault), csvHeaderClusterPerAZUtilization)
+	}
 	if opts.CSVRecFmt == CSVRecordFormatLong {
-		return csvHeaderClusterLong
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderClusterLong), csvHeaderClusterUtilization)
 	}
-	return csvHeaderClusterDefault
+	return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderClusterDefault), csvHeaderClusterUtilization)
 }
 
 // Render implements the LimesReportRenderer interface.
 func (c ClusterReport) render(opts *OutputOpts) CSVRecords {
+	if opts.PerAZ {
+		return c.renderPerAZ(opts)
+	}
 	var records CSVRecords
 
 	// Serialize service types with ordered keys
@@ -64,12 +88,17 @@ func (c ClusterReport) render(opts *OutputOpts) CSVRecords {
 			physU := cSrvRes.PhysicalUsage
 			domsQ := cSrvRes.DomainsQuota
 
+			var commitments resourceCommitments
+			for _, azRep := range cSrvRes.PerAZ {
+				commitments.add(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+			}
+
 			unit, formatter := cSrvRes.Unit, DefaultValueFormatter
 			if opts.Humanize {
-				unit, formatter = PickHumanizedValueFormatter(unit, []uint64{
+				unit, formatter = PickHumanizedValueFormatter(unit, append([]uint64{
 					zeroIfNil(capacity), zeroIfNil(physU), zeroIfNil(domsQ),
 					cSrvRes.Usage,
-				})
+				}, commitments.values()...))
 			}
 
 			if opts.CSVRecFmt == CSVRecordFormatLong {
@@ -83,9 +112,56 @@ func (c ClusterReport) render(opts *OutputOpts) CSVRecords {
 				)
 			}
 
-			records = append(records, r)
+			r = commitments.appendColumns(opts, r, formatter)
+			records = append(records, appendUtilizationColumns(opts, r, formatUtilization(cSrvRes.Usage, domsQ), formatUtilization(cSrvRes.Usage, capacity)))
 		}
 	}
 
 	return records
 }
+
+// renderPerAZ renders one record per service, resource and availability zone.
+func (c ClusterReport) renderPerAZ(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	for _, srv := range slices.Sorted(maps.Keys(c.Services)) {
+		cSrv := c.Services[srv]
+		for _, res := range slices.Sorted(maps.Keys(cSrv.Resources)) {
+			cSrvRes := cSrv.Resources[res]
+			perAZ := cSrvRes.PerAZ
+
+			// pick the same unit for all AZs of the resource
+			unit, formatter := cSrvRes.Unit, DefaultValueFormatter
+			if opts.Humanize {
+				var values []uint64
+				for _, azRep := range perAZ {
+					values = append(values, azRep.Capacity, zeroIfNil(azRep.Usage), azRep.ProjectsUsage,
+						zeroIfNil(azRep.PhysicalUsage), azRep.UnusedCommitments, azRep.UncommittedUsage,
+					)
+					values = append(values, newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments).values()...)
+				}
+				unit, formatter = PickHumanizedValueFormatter(unit, values)
+			}
+
+			for _, az := range slices.Sorted(maps.Keys(perAZ)) {
+				azRep := perAZ[az]
+				var r []string
+				if opts.CSVRecFmt == CSVRecordFormatLong {
+					r = append(r, c.ID, cSrv.Area, string(cSrv.Type), cSrvRes.Category, string(cSrvRes.Name), string(az),
+						formatter(azRep.Capacity), emptyStrIfNil(azRep.Usage, formatter), formatter(azRep.ProjectsUsage),
+						emptyStrIfNil(azRep.PhysicalUsage, formatter), formatter(azRep.UnusedCommitments),
+						formatter(azRep.UncommittedUsage), unit.String(), timestampToString(cSrv.MinScrapedAt),
+					)
+				} else {
+					r = append(r, c.ID, string(cSrv.Type), string(cSrvRes.Name), string(az),
+						formatter(azRep.Capacity), emptyStrIfNil(azRep.Usage, formatter), formatter(azRep.ProjectsUsage),
+						formatter(azRep.UnusedCommitments), formatter(azRep.UncommittedUsage), unit.String(),
+					)
+				}
+				commitments := newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+				r = commitments.appendColumns(opts, r, formatter)
+				records = append(records, appendUtilizationColumns(opts, r, formatUtilization(azRep.ProjectsUsage, &azRep.Capacity)))
+			}
+		}
+	}
+	return records
+}
@@ -42,4 +42,53 @@ func TestClusterResourcesSingleReportRender(t *testing.T) {
 	err = RenderReports(opts, rep).Write(&actual)
 	th.AssertNoErr(t, err)
 	assertEquals(t, "cluster-get-west-humanize.csv", actual.Bytes())
+
+	// test CSV rendering with utilization columns, sorted by usage/capacity
+	opts = &OutputOpts{
+		CSVRecFmt:   CSVRecordFormatDefault,
+		Utilization: true,
+		SortBy:      SortByUtilization,
+	}
+	actual = bytes.Buffer{}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-west-utilization.csv", actual.Bytes())
+}
+
+func TestClusterResourcesPerAZReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("cluster-get-per-az.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Cluster limesresources.ClusterReport `json:"cluster"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  false,
+		PerAZ:     true,
+	}
+	var actual bytes.Buffer
+	rep := ClusterReport{&data.Cluster}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-per-az.csv", actual.Bytes())
+
+	// all AZs of a resource are humanized with the same unit
+	opts.CSVRecFmt = CSVRecordFormatLong
+	opts.Humanize = true
+	actual = bytes.Buffer{}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-per-az-long-humanize.csv", actual.Bytes())
+
+	opts.CSVRecFmt = CSVRecordFormatDefault
+	opts.Humanize = false
+	opts.PerAZ = false
+	opts.CommitmentsByDuration = true
+	actual = bytes.Buffer{}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-commitments.csv", actual.Bytes())
 }
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"github.com/fatih/color"
+)
+
+var (
+	// colorDrift is used for values that differ between Limes and the backend.
+	colorDrift = newColor(color.FgRed, color.Bold)
+	// colorWarning and colorCritical are used for utilization values above
+	// the respective thresholds.
+	colorWarning  = newColor(color.FgYellow)
+	colorCritical = newColor(color.FgRed)
+)
+
+// newColor returns a color that is always rendered. Whether colors are used at
+// all is decided by OutputOpts.Color.
+func newColor(attrs ...color.Attribute) *color.Color {
+	c := color.New(attrs...)
+	c.EnableColor()
+	return c
+}
+
+// colorize renders a value in the given color, if colors are enabled.
+func colorize(opts *OutputOpts, c *color.Color, value string) string {
+	if !opts.Color || value == "" {
+		return value
+	}
+	return c.Sprint(value)
+}
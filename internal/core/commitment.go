@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// CommitmentsReport is a wrapper for the limesresources.Commitment list of a
+// single project.
+type CommitmentsReport struct {
+	Commitments []limesresources.Commitment
+
+	DomainID    string
+	DomainName  string
+	ProjectID   string
+	ProjectName string
+
+	// Now is the reference time for the remaining duration of each commitment.
+	// If zero, the remaining duration is not shown.
+	Now time.Time
+	// PreserveOrder disables sorting for listings that are already sorted in a
+	// meaningful way, e.g. by best fit.
+	PreserveOrder bool
+	// ShowTransferToken adds a column with the transfer token, which Limes
+	// only reports when a transfer is started.
+	ShowTransferToken bool
+}
+
+var csvHeaderCommitmentDefault = []string{
+	csvHeaderDomainID, csvHeaderProjectID, csvHeaderCommitmentUUID,
+	csvHeaderService, csvHeaderResource, csvHeaderAZ,
+	csvHeaderAmount, csvHeaderUnit, csvHeaderDuration, csvHeaderStatus,
+	csvHeaderConfirmBy, csvHeaderExpiresAt, csvHeaderTransferStatus,
+}
+
+var csvHeaderCommitmentLong = []string{
+	csvHeaderDomainID, csvHeaderDomainName, csvHeaderProjectID, csvHeaderProjectName,
+	csvHeaderCommitmentUUID, csvHeaderService, csvHeaderResource, csvHeaderAZ,
+	csvHeaderAmount, csvHeaderUnit, csvHeaderDuration, csvHeaderStatus,
+	csvHeaderCreatedAt, csvHeaderCreatorName, csvHeaderConfirmBy, csvHeaderConfirmedAt,
+	csvHeaderExpiresAt, csvHeaderTransferStatus, csvHeaderCanBeDeleted,
+	csvHeaderWasRenewed, csvHeaderNotifyOnConfirm,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (c CommitmentsReport) getHeaderRow(opts *OutputOpts) []string {
+	var h []string
+	switch opts.CSVRecFmt {
+	case CSVRecordFormatLong:
+		h = csvHeaderCommitmentLong
+	case CSVRecordFormatNames:
+		h = slices.Clone(csvHeaderCommitmentDefault)
+		h[0] = csvHeaderDomainName
+		h[1] = csvHeaderProjectName
+	default:
+		h = csvHeaderCommitmentDefault
+	}
+	if !c.Now.IsZero() {
+		idx := slices.Index(h, csvHeaderExpiresAt) + 1
+		h = slices.Insert(slices.Clone(h), idx, csvHeaderRemaining)
+	}
+	if c.ShowTransferToken {
+		h = append(slices.Clone(h), csvHeaderTransferToken)
+	}
+	return h
+}
+
+// Render implements the LimesReportRenderer interface.
+func (c CommitmentsReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize commitments in a stable order
+	commitments := slices.Clone(c.Commitments)
+	if !c.PreserveOrder {
+		slices.SortFunc(commitments, func(lhs, rhs limesresources.Commitment) int {
+			return cmp.Or(
+				cmp.Compare(lhs.ServiceType, rhs.ServiceType),
+				cmp.Compare(lhs.ResourceName, rhs.ResourceName),
+				cmp.Compare(lhs.AvailabilityZone, rhs.AvailabilityZone),
+				lhs.ExpiresAt.Compare(rhs.ExpiresAt.Time),
+				cmp.Compare(lhs.UUID, rhs.UUID),
+			)
+		})
+	}
+
+	for _, cm := range commitments {
+		var r []string
+
+		unit, formatter := cm.Unit, DefaultValueFormatter
+		if opts.Humanize {
+			unit, formatter = PickHumanizedValueFormatter(unit, []uint64{cm.Amount})
+		}
+
+		if opts.CSVRecFmt == CSVRecordFormatLong {
+			r = append(r, c.DomainID, c.DomainName, c.ProjectID, c.ProjectName,
+				cm.UUID, string(cm.ServiceType), string(cm.ResourceName), string(cm.AvailabilityZone),
+				formatter(cm.Amount), unit.String(), cm.Duration.String(), string(cm.Status),
+				timestampToString(&cm.CreatedAt), cm.CreatorName, timestampToString(cm.ConfirmBy),
+				timestampToString(cm.ConfirmedAt), timestampToString(&cm.ExpiresAt),
+			)
+			if !c.Now.IsZero() {
+				r = append(r, remainingDays(c.Now, cm.ExpiresAt.Time))
+			}
+			r = append(r, string(cm.TransferStatus),
+				strconv.FormatBool(cm.CanBeDeleted), strconv.FormatBool(cm.WasRenewed),
+				strconv.FormatBool(cm.NotifyOnConfirm),
+			)
+		} else {
+			projectNameOrID := c.ProjectID
+			domainNameOrID := c.DomainID
+			if opts.CSVRecFmt == CSVRecordFormatNames {
+				projectNameOrID = c.ProjectName
+				domainNameOrID = c.DomainName
+			}
+			r = append(r, domainNameOrID, projectNameOrID, cm.UUID,
+				string(cm.ServiceType), string(cm.ResourceName), string(cm.AvailabilityZone),
+				formatter(cm.Amount), unit.String(), cm.Duration.String(), string(cm.Status),
+				timestampToString(cm.ConfirmBy), timestampToString(&cm.ExpiresAt),
+			)
+			if !c.Now.IsZero() {
+				r = append(r, remainingDays(c.Now, cm.ExpiresAt.Time))
+			}
+			r = append(r, string(cm.TransferStatus))
+		}
+		if c.ShowTransferToken {
+			var token string
+			if cm.TransferToken != nil {
+				token = *cm.TransferToken
+			}
+			r = append(r, token)
+		}
+
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// remainingDays renders the number of full days between now and expiresAt.
+func remainingDays(now, expiresAt time.Time) string {
+	if !expiresAt.After(now) {
+		return "0 days"
+	}
+	days := int(expiresAt.Sub(now) / (24 * time.Hour))
+	if days == 1 {
+		return "1 day"
+	}
+	return strconv.Itoa(days) + " days"
+}
+
+var shortDurationFieldRx = regexp.MustCompile(`^(\d+)\s*([ymd])$`)
+
+var shortDurationUnits = map[string]string{"y": "year", "m": "month", "d": "day"}
+
+// ParseCommitmentDuration is like limesresources.ParseCommitmentDuration, but
+// also accepts the short forms "y", "m" and "d" for years, months and days,
+// e.g. "3y" or "1y, 6m".
+func ParseCommitmentDuration(input string) (limesresources.CommitmentDuration, error) {
+	fields := strings.Split(input, ",")
+	for idx, field := range fields {
+		match := shortDurationFieldRx.FindStringSubmatch(strings.TrimSpace(field))
+		if match == nil {
+			continue
+		}
+		unit := shortDurationUnits[match[2]]
+		if match[1] != "1" {
+			unit += "s"
+		}
+		fields[idx] = match[1] + " " + unit
+	}
+	return limesresources.ParseCommitmentDuration(strings.Join(fields, ","))
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// CommitmentConversionsReport is a wrapper for the
+// limesresources.CommitmentConversionRule list of a single resource.
+type CommitmentConversionsReport struct {
+	Rules []limesresources.CommitmentConversionRule
+
+	ServiceType  limes.ServiceType
+	ResourceName limesresources.ResourceName
+}
+
+var csvHeaderCommitmentConversion = []string{
+	csvHeaderService, csvHeaderResource, csvHeaderTargetService, csvHeaderTargetResource,
+	csvHeaderFromAmount, csvHeaderToAmount,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (c CommitmentConversionsReport) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderCommitmentConversion
+}
+
+// Render implements the LimesReportRenderer interface.
+func (c CommitmentConversionsReport) render(_ *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize rules with ordered target resources
+	rules := slices.Clone(c.Rules)
+	slices.SortFunc(rules, func(lhs, rhs limesresources.CommitmentConversionRule) int {
+		return cmp.Or(
+			cmp.Compare(lhs.TargetService, rhs.TargetService),
+			cmp.Compare(lhs.TargetResource, rhs.TargetResource),
+		)
+	})
+
+	for _, rule := range rules {
+		records = append(records, []string{
+			string(c.ServiceType), string(c.ResourceName), string(rule.TargetService), string(rule.TargetResource),
+			strconv.FormatUint(rule.FromAmount, 10), strconv.FormatUint(rule.ToAmount, 10),
+		})
+	}
+
+	return records
+}
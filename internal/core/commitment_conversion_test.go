@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestCommitmentConversionsReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("commitment-conversions.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Conversions []limesresources.CommitmentConversionRule `json:"conversions"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	var actual bytes.Buffer
+	rep := CommitmentConversionsReport{
+		Rules:        data.Conversions,
+		ServiceType:  "first",
+		ResourceName: "capacity",
+	}
+	err = RenderReports(&OutputOpts{}, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "commitment-conversions.csv", actual.Bytes())
+}
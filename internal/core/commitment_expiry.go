@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// CommitmentExpirySummary aggregates the commitments of several projects by
+// expiry month, service, resource and availability zone.
+type CommitmentExpirySummary struct {
+	Reports []CommitmentsReport
+}
+
+var csvHeaderCommitmentExpirySummary = []string{
+	csvHeaderExpiryMonth, csvHeaderService, csvHeaderResource, csvHeaderAZ,
+	csvHeaderCommitments, csvHeaderProjects, csvHeaderAmount, csvHeaderUnit,
+}
+
+type commitmentExpiryKey struct {
+	month        string
+	serviceType  limes.ServiceType
+	resourceName limesresources.ResourceName
+	az           limes.AvailabilityZone
+}
+
+type commitmentExpiryGroup struct {
+	commitments int
+	projectIDs  map[string]struct{}
+	amount      uint64
+	unit        limes.Unit
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (s CommitmentExpirySummary) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderCommitmentExpirySummary
+}
+
+// Render implements the LimesReportRenderer interface.
+func (s CommitmentExpirySummary) render(opts *OutputOpts) CSVRecords {
+	groups := make(map[commitmentExpiryKey]*commitmentExpiryGroup)
+	for _, rep := range s.Reports {
+		for _, cm := range rep.Commitments {
+			key := commitmentExpiryKey{
+				month:        cm.ExpiresAt.UTC().Format("2006-01"),
+				serviceType:  cm.ServiceType,
+				resourceName: cm.ResourceName,
+				az:           cm.AvailabilityZone,
+			}
+			g := groups[key]
+			if g == nil {
+				g = &commitmentExpiryGroup{projectIDs: make(map[string]struct{}), unit: cm.Unit}
+				groups[key] = g
+			}
+			g.commitments++
+			g.projectIDs[rep.ProjectID] = struct{}{}
+			g.amount += cm.Amount
+		}
+	}
+
+	// Serialize groups in a stable order
+	keys := make([]commitmentExpiryKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(lhs, rhs commitmentExpiryKey) int {
+		return cmp.Or(
+			cmp.Compare(lhs.month, rhs.month),
+			cmp.Compare(lhs.serviceType, rhs.serviceType),
+			cmp.Compare(lhs.resourceName, rhs.resourceName),
+			cmp.Compare(lhs.az, rhs.az),
+		)
+	})
+
+	var records CSVRecords
+	for _, key := range keys {
+		g := groups[key]
+		unit, formatter := g.unit, DefaultValueFormatter
+		if opts.Humanize {
+			unit, formatter = PickHumanizedValueFormatter(unit, []uint64{g.amount})
+		}
+		records = append(records, []string{
+			key.month, string(key.serviceType), string(key.resourceName), string(key.az),
+			strconv.Itoa(g.commitments), strconv.Itoa(len(g.projectIDs)),
+			formatter(g.amount), unit.String(),
+		})
+	}
+
+	return records
+}
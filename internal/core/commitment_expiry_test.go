@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestCommitmentExpirySummaryRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-list-commitments.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Commitments []limesresources.Commitment `json:"commitments"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	summary := CommitmentExpirySummary{
+		Reports: []CommitmentsReport{
+			{
+				Commitments: data.Commitments,
+				DomainID:    "uuid-for-germany",
+				ProjectID:   "uuid-for-berlin",
+			},
+			{
+				Commitments: data.Commitments[1:],
+				DomainID:    "uuid-for-germany",
+				ProjectID:   "uuid-for-dresden",
+			},
+		},
+	}
+
+	var actual bytes.Buffer
+	err = RenderReports(&OutputOpts{Humanize: true}, summary).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "commitment-expiry-summary.csv", actual.Bytes())
+}
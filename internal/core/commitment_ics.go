@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+const icsTimestampFormat = "20060102T150405Z"
+
+// WriteCommitmentsAsICS writes the given commitments to w as an iCalendar
+// (RFC 5545) file. It contains one event for the expiry of each commitment,
+// and one event for the confirmation deadline of each commitment that is not
+// confirmed yet.
+//
+// The UID of each event is derived from the commitment UUID, so that calendar
+// applications update existing events when the file is imported again.
+func WriteCommitmentsAsICS(w io.Writer, reps ...CommitmentsReport) error {
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//SAP SE//limesctl//EN",
+		"CALSCALE:GREGORIAN",
+	)
+
+	for _, rep := range reps {
+		dtstamp := rep.Now
+		if dtstamp.IsZero() {
+			dtstamp = time.Now()
+		}
+
+		for _, cm := range rep.Commitments {
+			lines = append(lines, icsEvent(rep, cm, dtstamp, "expires", "Commitment expires", cm.ExpiresAt.Time)...)
+			if cm.ConfirmedAt == nil && cm.ConfirmBy != nil {
+				lines = append(lines, icsEvent(rep, cm, dtstamp, "confirm-by", "Commitment confirmation deadline", cm.ConfirmBy.Time)...)
+			}
+		}
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(icsFoldLine(line))
+		sb.WriteString("\r\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	if err != nil {
+		return util.WrapError(err, "could not write iCalendar data")
+	}
+	return nil
+}
+
+func icsEvent(rep CommitmentsReport, cm limesresources.Commitment, dtstamp time.Time, kind, summaryPrefix string, at time.Time) []string {
+	projectNameOrID := rep.ProjectName
+	if projectNameOrID == "" {
+		projectNameOrID = rep.ProjectID
+	}
+	summary := fmt.Sprintf("%s: %s %s/%s %s %s", summaryPrefix, projectNameOrID,
+		cm.ServiceType, cm.ResourceName, cm.AvailabilityZone, FormatHumanizedValue(cm.Unit, cm.Amount))
+	description := fmt.Sprintf("Commitment %s in project %s (domain %s)\nDuration: %s\nStatus: %s",
+		cm.UUID, rep.ProjectID, rep.DomainID, cm.Duration.String(), cm.Status)
+
+	return []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%s-%s@limesctl", cm.UUID, kind),
+		"DTSTAMP:" + dtstamp.UTC().Format(icsTimestampFormat),
+		"DTSTART:" + at.UTC().Format(icsTimestampFormat),
+		"DTEND:" + at.UTC().Format(icsTimestampFormat),
+		"SUMMARY:" + icsEscapeText(summary),
+		"DESCRIPTION:" + icsEscapeText(description),
+		"END:VEVENT",
+	}
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// icsEscapeText escapes a value of type TEXT (RFC 5545, section 3.3.11).
+func icsEscapeText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// icsFoldLine splits content lines that are longer than 75 octets (RFC 5545,
+// section 3.1) without breaking multi-byte characters.
+func icsFoldLine(line string) string {
+	const maxLen = 75
+	var sb strings.Builder
+	lineLen := 0
+	for _, r := range line {
+		runeLen := len(string(r))
+		if lineLen+runeLen > maxLen {
+			sb.WriteString("\r\n ")
+			lineLen = 1
+		}
+		sb.WriteRune(r)
+		lineLen += runeLen
+	}
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestWriteCommitmentsAsICS(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-list-commitments.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Commitments []limesresources.Commitment `json:"commitments"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	rep := CommitmentsReport{
+		Commitments: data.Commitments,
+		DomainID:    "uuid-for-germany",
+		DomainName:  "germany",
+		ProjectID:   "uuid-for-berlin",
+		ProjectName: "berlin",
+		Now:         time.Unix(1720000000, 0).UTC(),
+	}
+
+	var actual bytes.Buffer
+	err = WriteCommitmentsAsICS(&actual, rep)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments.ics", actual.Bytes())
+}
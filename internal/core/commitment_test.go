@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestCommitmentsReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-list-commitments.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Commitments []limesresources.Commitment `json:"commitments"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	rep := CommitmentsReport{
+		Commitments: data.Commitments,
+		DomainID:    "uuid-for-germany",
+		DomainName:  "germany",
+		ProjectID:   "uuid-for-berlin",
+		ProjectName: "berlin",
+	}
+
+	// test CSV rendering with default format and humanize = false
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  false,
+	}
+	var actual bytes.Buffer
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments.csv", actual.Bytes())
+
+	// test CSV rendering with long format and humanize = true
+	opts = &OutputOpts{
+		CSVRecFmt: CSVRecordFormatLong,
+		Humanize:  true,
+	}
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments-long-humanize.csv", actual.Bytes())
+
+	// test remaining duration and preserved order (as used for listing public commitments)
+	rep.Now = time.Unix(1720000000, 0).UTC()
+	rep.PreserveOrder = true
+	opts = &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  true,
+	}
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments-remaining.csv", actual.Bytes())
+
+	// test transfer token column (as used when starting a transfer)
+	rep = CommitmentsReport{
+		Commitments:       data.Commitments[1:2],
+		DomainID:          "uuid-for-germany",
+		ProjectID:         "uuid-for-berlin",
+		ShowTransferToken: true,
+	}
+	opts = &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+	}
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "commitment-start-transfer.csv", actual.Bytes())
+}
+
+func TestParseCommitmentDuration(t *testing.T) {
+	for input, expected := range map[string]string{
+		"3 years": "3 years",
+		"3y":      "3 years",
+		"1y":      "1 year",
+		"1y, 6m":  "1 year, 6 months",
+		"30d":     "30 days",
+		"1 year":  "1 year",
+	} {
+		d, err := ParseCommitmentDuration(input)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, expected, d.String())
+	}
+
+	_, err := ParseCommitmentDuration("3x")
+	th.AssertErr(t, err)
+}
@@ -4,6 +4,7 @@
 package core
 
 import (
+	"maps"
 	"slices"
 
 	"github.com/sapcc/go-api-declarations/limes"
@@ -37,22 +38,49 @@ var csvHeaderDomainLong = []string{
 	csvHeaderUnit, csvHeaderScrapedAt,
 }
 
+var csvHeaderDomainPerAZDefault = []string{
+	csvHeaderDomainID, csvHeaderService, csvHeaderResource, csvHeaderAZ,
+	csvHeaderQuota, csvHeaderUsage,
+	csvHeaderUnit,
+}
+
+var csvHeaderDomainPerAZLong = []string{
+	csvHeaderDomainID, csvHeaderDomainName, csvHeaderArea, csvHeaderService, csvHeaderCategory, csvHeaderResource, csvHeaderAZ,
+	csvHeaderQuota, csvHeaderUsage, csvHeaderUnusedCommitments, csvHeaderUncommittedUsage,
+	csvHeaderUnit, csvHeaderScrapedAt,
+}
+
 // GetHeaderRow implements the LimesReportRenderer interface.
 func (d DomainReport) getHeaderRow(opts *OutputOpts) []string {
+	if opts.PerAZ {
+		switch opts.CSVRecFmt {
+		case CSVRecordFormatLong:
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderDomainPerAZLong), csvHeaderQuotaUtilization)
+		case CSVRecordFormatNames:
+			h := slices.Clone(csvHeaderDomainPerAZDefault)
+			h[0] = csvHeaderDomainName
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, h), csvHeaderQuotaUtilization)
+		default:
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderDomainPerAZDefault), csvHeaderQuotaUtilization)
+		}
+	}
 	switch opts.CSVRecFmt {
 	case CSVRecordFormatLong:
-		return csvHeaderDomainLong
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderDomainLong), csvHeaderQuotaUtilization)
 	case CSVRecordFormatNames:
 		h := csvHeaderDomainDefault
 		h[0] = csvHeaderDomainName
-		return h
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, h), csvHeaderQuotaUtilization)
 	default:
-		return csvHeaderDomainDefault
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderDomainDefault), csvHeaderQuotaUtilization)
 	}
 }
 
 // Render implements the LimesReportRenderer interface.
 func (d DomainReport) render(opts *OutputOpts) CSVRecords {
+	if opts.PerAZ {
+		return d.renderPerAZ(opts)
+	}
 	var records CSVRecords
 
 	// Serialize service types with ordered keys
@@ -80,12 +108,17 @@ func (d DomainReport) render(opts *OutputOpts) CSVRecords {
 			domQ := dSrvRes.DomainQuota
 			projectsQ := dSrvRes.ProjectsQuota
 
+			var commitments resourceCommitments
+			for _, azRep := range dSrvRes.PerAZ {
+				commitments.add(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+			}
+
 			unit, formatter := dSrvRes.Unit, DefaultValueFormatter
 			if opts.Humanize {
-				unit, formatter = PickHumanizedValueFormatter(unit, []uint64{
+				unit, formatter = PickHumanizedValueFormatter(unit, append([]uint64{
 					zeroIfNil(physU), zeroIfNil(domQ), zeroIfNil(projectsQ),
 					dSrvRes.Usage,
-				})
+				}, commitments.values()...))
 			}
 
 			if opts.CSVRecFmt == CSVRecordFormatLong {
@@ -103,9 +136,58 @@ func (d DomainReport) render(opts *OutputOpts) CSVRecords {
 				)
 			}
 
-			records = append(records, r)
+			r = commitments.appendColumns(opts, r, formatter)
+			records = append(records, appendUtilizationColumns(opts, r, formatUtilization(dSrvRes.Usage, domQ)))
 		}
 	}
 
 	return records
 }
+
+// renderPerAZ renders one record per service, resource and availability zone.
+func (d DomainReport) renderPerAZ(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	for _, srv := range slices.Sorted(maps.Keys(d.Services)) {
+		dSrv := d.Services[srv]
+		for _, res := range slices.Sorted(maps.Keys(dSrv.Resources)) {
+			dSrvRes := dSrv.Resources[res]
+
+			// pick the same unit for all AZs of the resource
+			unit, formatter := dSrvRes.Unit, DefaultValueFormatter
+			if opts.Humanize {
+				var values []uint64
+				for _, azRep := range dSrvRes.PerAZ {
+					values = append(values, zeroIfNil(azRep.Quota), azRep.Usage,
+						azRep.UnusedCommitments, azRep.UncommittedUsage,
+					)
+					values = append(values, newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments).values()...)
+				}
+				unit, formatter = PickHumanizedValueFormatter(unit, values)
+			}
+
+			for _, az := range slices.Sorted(maps.Keys(dSrvRes.PerAZ)) {
+				azRep := dSrvRes.PerAZ[az]
+				var r []string
+				if opts.CSVRecFmt == CSVRecordFormatLong {
+					r = append(r, d.UUID, d.Name, dSrv.Area, string(dSrv.Type), dSrvRes.Category, string(dSrvRes.Name), string(az),
+						emptyStrIfNil(azRep.Quota, formatter), formatter(azRep.Usage),
+						formatter(azRep.UnusedCommitments), formatter(azRep.UncommittedUsage),
+						unit.String(), timestampToString(dSrv.MinScrapedAt),
+					)
+				} else {
+					nameOrID := d.UUID
+					if opts.CSVRecFmt == CSVRecordFormatNames {
+						nameOrID = d.Name
+					}
+					r = append(r, nameOrID, string(dSrv.Type), string(dSrvRes.Name), string(az),
+						emptyStrIfNil(azRep.Quota, formatter), formatter(azRep.Usage), unit.String(),
+					)
+				}
+				commitments := newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+				r = commitments.appendColumns(opts, r, formatter)
+				records = append(records, appendUtilizationColumns(opts, r, formatUtilization(azRep.Usage, azRep.Quota)))
+			}
+		}
+	}
+	return records
+}
@@ -69,3 +69,65 @@ func TestDomainResourcesMultipleReportsRender(t *testing.T) {
 	th.AssertNoErr(t, err)
 	assertEquals(t, "domain-list-filtered.csv", actual.Bytes())
 }
+
+func TestDomainResourcesUtilizationRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("domain-list.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Domains []limesresources.DomainReport `json:"domains"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	// rows are ranked by utilization across all domains, and utilization
+	// values above the thresholds are highlighted
+	opts := &OutputOpts{
+		CSVRecFmt:   CSVRecordFormatDefault,
+		Humanize:    false,
+		Color:       true,
+		Utilization: true,
+		WarnAt:      10,
+		CritAt:      15,
+		SortBy:      SortByUtilization,
+	}
+	var actual bytes.Buffer
+	reps := LimesDomainsToReportRenderer(data.Domains)
+	err = RenderReports(opts, reps...).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-list-utilization.csv", actual.Bytes())
+
+	// JSON rows are sorted in the same way, but never highlighted
+	opts.Color = false
+	actual.Reset()
+	err = WriteReportsAsJSONRows(&actual, opts, true, reps...)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-list-utilization.ndjson", actual.Bytes())
+}
+
+func TestDomainResourcesPerAZReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("domain-get-per-az.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Domain limesresources.DomainReport `json:"domain"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatNames,
+		Humanize:  false,
+		PerAZ:     true,
+	}
+	var actual bytes.Buffer
+	rep := DomainReport{&data.Domain}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-get-per-az-names.csv", actual.Bytes())
+
+	opts.CSVRecFmt = CSVRecordFormatLong
+	opts.Humanize = true
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-get-per-az-long-humanize.csv", actual.Bytes())
+}
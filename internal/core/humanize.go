@@ -5,6 +5,7 @@ package core
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/sapcc/go-api-declarations/limes"
 )
@@ -84,3 +85,34 @@ UNIT:
 	// defense in depth: if (somehow!) no candidate was viable, not humanizing is a safe fallback
 	return unit, DefaultValueFormatter
 }
+
+// FormatHumanizedValue renders a single value in the best human-readable unit,
+// e.g. "2 GiB" for 2048 MiB. Values of countable resources are rendered without a unit.
+func FormatHumanizedValue(unit limes.Unit, value uint64) string {
+	formatter := DefaultValueFormatter
+	if value == 0 {
+		// for zero, every unit would fit, so use the base unit to avoid
+		// results like "0 2032 MiB" for non-standard units
+		unit, _ = unit.Base()
+	} else {
+		unit, formatter = PickHumanizedValueFormatter(unit, []uint64{value})
+	}
+	if unit.String() == "" {
+		return formatter(value)
+	}
+	return formatter(value) + " " + unit.String()
+}
+
+// ParseValueInUnit parses a value like "10 TiB" and converts it into the given
+// unit, like limes.ParseInUnit. In addition, the space between number and unit
+// may be omitted, e.g. "10TiB".
+func ParseValueInUnit(unit limes.Unit, str string) (uint64, error) {
+	str = strings.TrimSpace(str)
+	idx := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx > 0 && str[idx] != ' ' {
+		str = str[:idx] + " " + str[idx:]
+	}
+	return limes.ParseInUnit(unit, str)
+}
@@ -39,4 +39,25 @@ func TestValueFormatter(t *testing.T) {
 	assert.Equal(t, u.String(), "GiB")
 	assert.Equal(t, f(64), "127")
 	assert.Equal(t, f(128), "254")
+
+	// FormatHumanizedValue renders a single value together with its unit
+	assert.Equal(t, FormatHumanizedValue(limes.UnitMebibytes, 2048), "2 GiB")
+	assert.Equal(t, FormatHumanizedValue(limes.UnitMebibytes, 1000), "1000 MiB")
+	assert.Equal(t, FormatHumanizedValue(limes.UnitNone, 42), "42")
+	assert.Equal(t, FormatHumanizedValue(limes.UnitBytes, 0), "0 B")
+	assert.Equal(t, FormatHumanizedValue(weirdUnit, 0), "0 B")
+}
+
+func TestParseValueInUnit(t *testing.T) {
+	// the space between number and unit is optional
+	assert.Equal(t, must.Return(ParseValueInUnit(limes.UnitGibibytes, "10 TiB")), uint64(10240))
+	assert.Equal(t, must.Return(ParseValueInUnit(limes.UnitGibibytes, "10TiB")), uint64(10240))
+	assert.Equal(t, must.Return(ParseValueInUnit(limes.UnitMebibytes, " 512MiB ")), uint64(512))
+	assert.Equal(t, must.Return(ParseValueInUnit(limes.UnitNone, "42")), uint64(42))
+
+	// values that cannot be converted into the unit are still rejected
+	_, err := ParseValueInUnit(limes.UnitGibibytes, "512MiB")
+	assert.Equal(t, err != nil, true)
+	_, err = ParseValueInUnit(limes.UnitGibibytes, "TiB")
+	assert.Equal(t, err != nil, true)
 }
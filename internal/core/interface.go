@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 
@@ -22,8 +23,26 @@ const (
 	OutputFormatTable OutputFormat = "table"
 	OutputFormatCSV   OutputFormat = "csv"
 	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatYAML  OutputFormat = "yaml"
+	// The JSON rows formats contain the same rows as the CSV format.
+	OutputFormatJSONRows OutputFormat = "json-rows"
+	OutputFormatNDJSON   OutputFormat = "ndjson"
+	// OutputFormatICS is only supported for commitment listings.
+	OutputFormatICS OutputFormat = "ics"
+	// The template formats require an argument, e.g. "jsonpath={.services[*].type}".
+	OutputFormatTemplate     OutputFormat = "template"
+	OutputFormatTemplateFile OutputFormat = "template-file"
+	OutputFormatJSONPath     OutputFormat = "jsonpath"
 )
 
+// SplitOutputFormat separates the kind of an OutputFormat from its argument,
+// e.g. "jsonpath={.services}" is split into OutputFormatJSONPath and
+// "{.services}". For formats without argument, the argument is empty.
+func SplitOutputFormat(f OutputFormat) (kind OutputFormat, arg string) {
+	kindStr, arg, _ := strings.Cut(string(f), "=")
+	return OutputFormat(kindStr), arg
+}
+
 // String implements the pflag.Value interface.
 func (f *OutputFormat) String() string {
 	return string(*f)
@@ -31,13 +50,26 @@ func (f *OutputFormat) String() string {
 
 // Set implements the pflag.Value interface.
 func (f *OutputFormat) Set(v string) error {
-	switch vf := OutputFormat(v); vf {
-	case OutputFormatTable, OutputFormatCSV, OutputFormatJSON:
-		*f = vf
+	kind, arg := SplitOutputFormat(OutputFormat(v))
+	switch kind {
+	case OutputFormatTable, OutputFormatCSV, OutputFormatJSON, OutputFormatYAML,
+		OutputFormatJSONRows, OutputFormatNDJSON, OutputFormatICS:
+		if kind != OutputFormat(v) {
+			return fmt.Errorf("%s does not take an argument", kind)
+		}
+		*f = kind
+		return nil
+	case OutputFormatTemplate, OutputFormatTemplateFile, OutputFormatJSONPath:
+		if arg == "" {
+			return fmt.Errorf("%s requires an argument, e.g. %s=...", kind, kind)
+		}
+		*f = OutputFormat(v)
 		return nil
 	default:
-		return fmt.Errorf("must be one of [%s, %s, %s], got %s",
-			OutputFormatTable, OutputFormatCSV, OutputFormatJSON, v)
+		return fmt.Errorf("must be one of [%s, %s, %s, %s, %s, %s, %s, %s=..., %s=..., %s=...], got %s",
+			OutputFormatTable, OutputFormatCSV, OutputFormatJSON, OutputFormatYAML,
+			OutputFormatJSONRows, OutputFormatNDJSON, OutputFormatICS,
+			OutputFormatTemplate, OutputFormatTemplateFile, OutputFormatJSONPath, v)
 	}
 }
 
@@ -84,6 +116,23 @@ type OutputOpts struct {
 	Fmt       OutputFormat
 	CSVRecFmt CSVRecordFormat
 	Humanize  bool
+	// PerAZ, Commitments and CommitmentsByDuration are only supported for
+	// cluster, domain and project resource reports.
+	PerAZ                 bool
+	Commitments           bool
+	CommitmentsByDuration bool
+	// Color enables highlighting of values with ANSI colors. It shall only be
+	// set for table output on a terminal.
+	Color bool
+	// Utilization adds columns with usage as a percentage of quota and/or
+	// capacity to resource reports.
+	Utilization bool
+	// WarnAt and CritAt are utilization percentages above which utilization
+	// values are highlighted. Zero disables the respective threshold.
+	WarnAt float64
+	CritAt float64
+	// SortBy is either empty (to keep the order of the reports) or SortByUtilization.
+	SortBy string
 }
 
 // LimesReportRenderer is implemented by data types that can render a Limes
@@ -104,6 +153,7 @@ func RenderReports(opts *OutputOpts, rL ...LimesReportRenderer) CSVRecords {
 		for _, r := range rL {
 			recs = append(recs, r.render(opts)...)
 		}
+		applyUtilizationOpts(opts, recs)
 	}
 	return recs
 }
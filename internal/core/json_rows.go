@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// textColumns are the columns whose values are always written as strings in
+// JSON rows, even if they look like numbers (e.g. a project named "123").
+var textColumns = []string{
+	csvHeaderClusterID, csvHeaderDomainID, csvHeaderDomainName,
+	csvHeaderProjectID, csvHeaderProjectName,
+	csvHeaderArea, csvHeaderService, csvHeaderCategory, csvHeaderResource, csvHeaderRate,
+	csvHeaderCommitmentUUID, csvHeaderAZ, csvHeaderCreatorName, csvHeaderTransferToken,
+	csvHeaderTargetService, csvHeaderTargetResource, csvHeaderUnit,
+	csvHeaderID, csvHeaderName,
+}
+
+// WriteReportsAsJSONRows writes the same rows as RenderReports, but as JSON
+// objects that are keyed by the header names. Numbers and booleans are
+// written as such, empty values are written as null.
+//
+// With ndjson set, each row is written on its own line as soon as its report
+// has been rendered. Otherwise, all rows are written as a single JSON array.
+// When the rows shall be sorted, all reports are rendered before writing.
+func WriteReportsAsJSONRows(w io.Writer, opts *OutputOpts, ndjson bool, rL ...LimesReportRenderer) error {
+	if opts.SortBy != "" && len(rL) > 0 {
+		recs := RenderReports(opts, rL...)
+		rL = []LimesReportRenderer{renderedRecords(recs)}
+	}
+
+	var header []string
+	if len(rL) > 0 {
+		header = rL[0].getHeaderRow(opts)
+	}
+
+	var (
+		buf      bytes.Buffer
+		rowCount int
+	)
+	if !ndjson {
+		buf.WriteString("[")
+	}
+	for _, r := range rL {
+		for _, rec := range r.render(opts) {
+			if !ndjson && rowCount > 0 {
+				buf.WriteString(",")
+			}
+			err := writeJSONRow(&buf, header, rec)
+			if err != nil {
+				return err
+			}
+			if ndjson {
+				buf.WriteString("\n")
+			}
+			rowCount++
+		}
+
+		// flush after each report so that large listings can be processed incrementally
+		_, err := w.Write(buf.Bytes())
+		if err != nil {
+			return util.WrapError(err, "could not write JSON data")
+		}
+		buf.Reset()
+	}
+	if !ndjson {
+		buf.WriteString("]\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	if err != nil {
+		return util.WrapError(err, "could not write JSON data")
+	}
+	return nil
+}
+
+// writeJSONRow writes a single record as a JSON object. The keys appear in the
+// same order as in the header.
+func writeJSONRow(buf *bytes.Buffer, header, rec []string) error {
+	buf.WriteString("{")
+	for idx, key := range header {
+		if idx >= len(rec) {
+			break
+		}
+		if idx > 0 {
+			buf.WriteString(",")
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return util.WrapError(err, "could not marshal JSON")
+		}
+		valueJSON, err := json.Marshal(typedJSONRowValue(key, rec[idx]))
+		if err != nil {
+			return util.WrapError(err, "could not marshal JSON")
+		}
+		buf.Write(keyJSON)
+		buf.WriteString(":")
+		buf.Write(valueJSON)
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// typedJSONRowValue converts a rendered value back into a number or boolean,
+// where possible.
+func typedJSONRowValue(column, value string) any {
+	switch {
+	case value == "":
+		return nil
+	case slices.Contains(textColumns, column):
+		return value
+	case value == "true" || value == "false":
+		return value == "true"
+	}
+	// only accept numbers in the exact syntax that JSON allows
+	var number json.Number
+	if json.Unmarshal([]byte(value), &number) == nil {
+		return number
+	}
+	return value
+}
+
+// renderedRecords wraps records that were already rendered by RenderReports,
+// with the header row as the first record.
+type renderedRecords CSVRecords
+
+func (r renderedRecords) getHeaderRow(_ *OutputOpts) []string {
+	return r[0]
+}
+
+func (r renderedRecords) render(_ *OutputOpts) CSVRecords {
+	return CSVRecords(r[1:])
+}
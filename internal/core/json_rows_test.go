@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestWriteReportsAsJSONRows(t *testing.T) {
+	type listData struct {
+		Domains []limesresources.DomainReport `json:"domains"`
+	}
+
+	// NDJSON with one line per row
+	mockJSONBytes, err := fixtureBytes("domain-list.json")
+	th.AssertNoErr(t, err)
+	var data listData
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  false,
+	}
+	var actual bytes.Buffer
+	err = WriteReportsAsJSONRows(&actual, opts, true, LimesDomainsToReportRenderer(data.Domains)...)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-list.ndjson", actual.Bytes())
+
+	// JSON array with long CSV format and human-readable values
+	mockJSONBytes, err = fixtureBytes("domain-list-filtered.json")
+	th.AssertNoErr(t, err)
+	var filteredData listData
+	err = json.Unmarshal(mockJSONBytes, &filteredData)
+	th.AssertNoErr(t, err)
+
+	opts.CSVRecFmt = CSVRecordFormatLong
+	opts.Humanize = true
+	actual.Reset()
+	err = WriteReportsAsJSONRows(&actual, opts, false, LimesDomainsToReportRenderer(filteredData.Domains)...)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "domain-list-filtered-rows.json", actual.Bytes())
+
+	// commitments in long format contain booleans
+	mockJSONBytes, err = fixtureBytes("project-list-commitments.json")
+	th.AssertNoErr(t, err)
+	var commitmentData struct {
+		Commitments []limesresources.Commitment `json:"commitments"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &commitmentData)
+	th.AssertNoErr(t, err)
+
+	opts.CSVRecFmt = CSVRecordFormatLong
+	opts.Humanize = false
+	actual.Reset()
+	rep := CommitmentsReport{
+		Commitments: commitmentData.Commitments,
+		DomainID:    "uuid-for-germany",
+		DomainName:  "germany",
+		ProjectID:   "uuid-for-berlin",
+		ProjectName: "berlin",
+	}
+	err = WriteReportsAsJSONRows(&actual, opts, true, rep)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments-long.ndjson", actual.Bytes())
+
+	// no reports still yield a valid JSON array
+	actual.Reset()
+	err = WriteReportsAsJSONRows(&actual, opts, false)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "[]\n", actual.String())
+}
@@ -0,0 +1,645 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// This file contains an implementation of the JSONPath template syntax that
+// kubectl uses for its '-o jsonpath=...' option. The following subset is
+// supported:
+//
+//	literal text                  is printed as-is
+//	{"\n"}                        quoted strings are unquoted and printed
+//	{.services[*].type}           field access, wildcards and indexes
+//	{$.services[0:2].type}        slices; "$" refers to the document root
+//	{.services[0,1].type}         unions of indexes or field names
+//	{..name}                      recursive descent
+//	{.resources[?(@.usage>0)]}    filters with ==, !=, <, <=, >, >= or existence;
+//	                              values are strings, numbers, true or false
+//	{range .services[*]}...{end}  iteration; "." and "@" refer to the current item
+//
+// Fields that do not exist are skipped, because Limes omits empty fields in its
+// responses. If an expression yields multiple values, they are separated by
+// spaces.
+
+type jsonPathNode interface{}
+
+type jsonPathText string
+
+type jsonPathPrint jsonPathExpr
+
+type jsonPathRange struct {
+	expr jsonPathExpr
+	body []jsonPathNode
+}
+
+// jsonPathExpr is a sequence of steps. If fromRoot is true, evaluation starts
+// at the document root instead of the current item.
+type jsonPathExpr struct {
+	fromRoot bool
+	steps    []jsonPathStep
+}
+
+// jsonPathStep maps a single value to a list of values.
+type jsonPathStep func(value any) ([]any, error)
+
+// parseJSONPathTemplate parses a template like "{range .services[*]}{.type}{end}".
+func parseJSONPathTemplate(input string) ([]jsonPathNode, error) {
+	var (
+		root  []jsonPathNode
+		stack []*jsonPathRange
+	)
+	appendNode := func(node jsonPathNode) {
+		if len(stack) > 0 {
+			r := stack[len(stack)-1]
+			r.body = append(r.body, node)
+		} else {
+			root = append(root, node)
+		}
+	}
+
+	rest := input
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			appendNode(jsonPathText(rest))
+			break
+		}
+		if start > 0 {
+			appendNode(jsonPathText(rest[:start]))
+		}
+		end := findClosingBrace(rest, start)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed action in %q", rest[start:])
+		}
+		action := strings.TrimSpace(rest[start+1 : end])
+		rest = rest[end+1:]
+
+		switch {
+		case action == "end":
+			if len(stack) == 0 {
+				return nil, errors.New(`unexpected "{end}" without "{range}"`)
+			}
+			r := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			appendNode(r)
+		case strings.HasPrefix(action, "range "):
+			expr, err := parseJSONPathExpr(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, &jsonPathRange{expr: expr})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal %s: %w", action, err)
+			}
+			appendNode(jsonPathText(text))
+		default:
+			expr, err := parseJSONPathExpr(action)
+			if err != nil {
+				return nil, err
+			}
+			appendNode(jsonPathPrint(expr))
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, errors.New(`missing "{end}" for "{range}"`)
+	}
+	return root, nil
+}
+
+// findClosingBrace returns the index of the brace that closes the one at
+// input[start], ignoring braces in quoted strings.
+func findClosingBrace(input string, start int) int {
+	var quote byte
+	for idx := start + 1; idx < len(input); idx++ {
+		c := input[idx]
+		switch {
+		case quote != 0 && c == '\\':
+			idx++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return idx
+		}
+	}
+	return -1
+}
+
+// parseJSONPathExpr parses an expression like ".services[*].type".
+func parseJSONPathExpr(input string) (jsonPathExpr, error) {
+	var expr jsonPathExpr
+	rest := input
+	switch {
+	case strings.HasPrefix(rest, "$"):
+		expr.fromRoot = true
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "@"):
+		rest = rest[1:]
+	case !strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "["):
+		return expr, fmt.Errorf("invalid expression %q: must start with \".\", \"[\", \"$\" or \"@\"", input)
+	}
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			expr.steps = append(expr.steps, jsonPathRecursiveDescent)
+			// continue with the field or subscript after ".."
+			if strings.HasPrefix(rest, "..[") {
+				rest = rest[2:]
+			} else {
+				rest = rest[1:]
+			}
+			if rest == "." {
+				return expr, fmt.Errorf("invalid expression %q: missing field after \"..\"", input)
+			}
+		case rest == ".":
+			// "{.}" refers to the current item
+			rest = ""
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			rest = rest[end+1:]
+			if name == "*" {
+				expr.steps = append(expr.steps, jsonPathWildcard)
+			} else {
+				expr.steps = append(expr.steps, jsonPathField(name))
+			}
+		case strings.HasPrefix(rest, "["):
+			end := findClosingBracket(rest)
+			if end < 0 {
+				return expr, fmt.Errorf("invalid expression %q: unclosed bracket", input)
+			}
+			step, err := parseJSONPathSubscript(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return expr, fmt.Errorf("invalid expression %q: %w", input, err)
+			}
+			expr.steps = append(expr.steps, step)
+			rest = rest[end+1:]
+		default:
+			return expr, fmt.Errorf("invalid expression %q: unexpected %q", input, rest)
+		}
+	}
+	return expr, nil
+}
+
+// findClosingBracket returns the index of the bracket that closes the one at
+// input[0], ignoring brackets in quoted strings and nested parentheses.
+func findClosingBracket(input string) int {
+	var (
+		quote byte
+		depth int
+	)
+	for idx := 1; idx < len(input); idx++ {
+		c := input[idx]
+		switch {
+		case quote != 0 && c == '\\':
+			idx++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || (c == ']' && depth > 0):
+			depth--
+		case c == ']':
+			return idx
+		}
+	}
+	return -1
+}
+
+func parseJSONPathSubscript(input string) (jsonPathStep, error) {
+	if !strings.HasPrefix(input, "?(") {
+		if parts := splitJSONPathUnion(input); len(parts) > 1 {
+			return parseJSONPathUnion(parts)
+		}
+	}
+
+	switch {
+	case input == "":
+		return nil, errors.New("empty subscript")
+	case input == "*":
+		return jsonPathWildcard, nil
+	case strings.HasPrefix(input, "'") || strings.HasPrefix(input, `"`):
+		name, err := unquoteJSONPathString(input)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathField(name), nil
+	case strings.HasPrefix(input, "?(") && strings.HasSuffix(input, ")"):
+		return parseJSONPathFilter(strings.TrimSpace(input[2 : len(input)-1]))
+	case strings.Contains(input, ":"):
+		startStr, endStr, _ := strings.Cut(input, ":")
+		var (
+			start, end       int
+			hasStart, hasEnd bool
+			err              error
+		)
+		if startStr = strings.TrimSpace(startStr); startStr != "" {
+			start, err = strconv.Atoi(startStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid slice %q", input)
+			}
+			hasStart = true
+		}
+		if endStr = strings.TrimSpace(endStr); endStr != "" {
+			end, err = strconv.Atoi(endStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid slice %q", input)
+			}
+			hasEnd = true
+		}
+		return func(value any) ([]any, error) {
+			list, ok := value.([]any)
+			if !ok {
+				return nil, nil
+			}
+			from, to := 0, len(list)
+			if hasStart {
+				from = normalizeJSONPathIndex(start, len(list))
+			}
+			if hasEnd {
+				to = normalizeJSONPathIndex(end, len(list))
+			}
+			if from >= to {
+				return nil, nil
+			}
+			return list[from:to], nil
+		}, nil
+	default:
+		index, err := strconv.Atoi(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subscript %q", input)
+		}
+		return func(value any) ([]any, error) {
+			list, ok := value.([]any)
+			if !ok {
+				return nil, nil
+			}
+			idx := index
+			if idx < 0 {
+				idx += len(list)
+			}
+			if idx < 0 || idx >= len(list) {
+				return nil, fmt.Errorf("index %d is out of range for array of length %d", index, len(list))
+			}
+			return []any{list[idx]}, nil
+		}, nil
+	}
+}
+
+// splitJSONPathUnion splits a subscript like "0,1" or "'a','b'" at the commas
+// outside of quoted strings.
+func splitJSONPathUnion(input string) []string {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+	for idx := 0; idx < len(input); idx++ {
+		c := input[idx]
+		switch {
+		case quote != 0 && c == '\\':
+			idx++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			parts = append(parts, strings.TrimSpace(input[start:idx]))
+			start = idx + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(input[start:]))
+}
+
+// parseJSONPathUnion returns a step that concatenates the results of the
+// subscripts in a union.
+func parseJSONPathUnion(parts []string) (jsonPathStep, error) {
+	steps := make([]jsonPathStep, len(parts))
+	for idx, part := range parts {
+		if strings.HasPrefix(part, "?(") {
+			return nil, fmt.Errorf("filters are not allowed in unions: %q", part)
+		}
+		step, err := parseJSONPathSubscript(part)
+		if err != nil {
+			return nil, err
+		}
+		steps[idx] = step
+	}
+	return func(value any) ([]any, error) {
+		var result []any
+		for _, step := range steps {
+			values, err := step(value)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, values...)
+		}
+		return result, nil
+	}, nil
+}
+
+func normalizeJSONPathIndex(idx, length int) int {
+	if idx < 0 {
+		idx += length
+	}
+	return max(0, min(idx, length))
+}
+
+func unquoteJSONPathString(input string) (string, error) {
+	if strings.HasPrefix(input, "'") {
+		if len(input) < 2 || !strings.HasSuffix(input, "'") {
+			return "", fmt.Errorf("invalid string literal %s", input)
+		}
+		return input[1 : len(input)-1], nil
+	}
+	s, err := strconv.Unquote(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid string literal %s", input)
+	}
+	return s, nil
+}
+
+var jsonPathFilterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseJSONPathFilter parses the inside of a filter like "?(@.usage > 0)".
+func parseJSONPathFilter(input string) (jsonPathStep, error) {
+	idx, op := findJSONPathFilterOperator(input)
+	found := idx >= 0
+	lhsStr, rhsStr := input, ""
+	if found {
+		lhsStr, rhsStr = input[:idx], input[idx+len(op):]
+	}
+
+	lhs, err := parseJSONPathExpr(strings.TrimSpace(lhsStr))
+	if err != nil {
+		return nil, err
+	}
+	var rhs any
+	if found {
+		rhsStr = strings.TrimSpace(rhsStr)
+		switch {
+		case strings.HasPrefix(rhsStr, "'") || strings.HasPrefix(rhsStr, `"`):
+			rhs, err = unquoteJSONPathString(rhsStr)
+		case rhsStr == "true" || rhsStr == "false":
+			rhs = rhsStr == "true"
+		default:
+			rhs, err = strconv.ParseFloat(rhsStr, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in filter %q", input)
+		}
+	}
+
+	return func(value any) ([]any, error) {
+		var candidates []any
+		switch v := value.(type) {
+		case []any:
+			candidates = v
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				candidates = append(candidates, v[key])
+			}
+		}
+
+		var result []any
+		for _, candidate := range candidates {
+			values, err := lhs.evaluate(candidate, candidate)
+			if err != nil {
+				return nil, err
+			}
+			if len(values) == 0 {
+				continue
+			}
+			if !found || compareJSONPathValues(values[0], op, rhs) {
+				result = append(result, candidate)
+			}
+		}
+		return result, nil
+	}, nil
+}
+
+// findJSONPathFilterOperator returns the position of the first comparison
+// operator in a filter, ignoring operators in quoted strings, or -1 if there
+// is none.
+func findJSONPathFilterOperator(input string) (int, string) {
+	var quote byte
+	for idx := 0; idx < len(input); idx++ {
+		c := input[idx]
+		switch {
+		case quote != 0 && c == '\\':
+			idx++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		default:
+			for _, op := range jsonPathFilterOperators {
+				if strings.HasPrefix(input[idx:], op) {
+					return idx, op
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+func compareJSONPathValues(lhs any, op string, rhs any) bool {
+	var cmp int
+	switch r := rhs.(type) {
+	case bool:
+		// booleans can only be compared for equality
+		l, ok := lhs.(bool)
+		switch op {
+		case "==":
+			return ok && l == r
+		case "!=":
+			return !ok || l != r
+		default:
+			return false
+		}
+	case string:
+		l, ok := lhs.(string)
+		if !ok {
+			return op == "!="
+		}
+		cmp = strings.Compare(l, r)
+	case float64:
+		n, ok := lhs.(json.Number)
+		if !ok {
+			return op == "!="
+		}
+		l, err := n.Float64()
+		if err != nil {
+			return op == "!="
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+func jsonPathField(name string) jsonPathStep {
+	return func(value any) ([]any, error) {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		field, exists := obj[name]
+		if !exists {
+			return nil, nil
+		}
+		return []any{field}, nil
+	}
+}
+
+func jsonPathWildcard(value any) ([]any, error) {
+	switch v := value.(type) {
+	case []any:
+		return v, nil
+	case map[string]any:
+		result := make([]any, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			result = append(result, v[key])
+		}
+		return result, nil
+	default:
+		return nil, nil
+	}
+}
+
+// jsonPathRecursiveDescent returns the value and all values nested in it, in
+// depth-first order. Like in kubectl, only arrays and objects are returned,
+// since the following step selects fields or elements from them.
+func jsonPathRecursiveDescent(value any) ([]any, error) {
+	children, err := jsonPathWildcard(value)
+	if err != nil || len(children) == 0 {
+		return nil, err
+	}
+	result := []any{value}
+	for _, child := range children {
+		nested, err := jsonPathRecursiveDescent(child)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, nested...)
+	}
+	return result, nil
+}
+
+func (e jsonPathExpr) evaluate(root, current any) ([]any, error) {
+	values := []any{current}
+	if e.fromRoot {
+		values = []any{root}
+	}
+	for _, step := range e.steps {
+		var next []any
+		for _, v := range values {
+			result, err := step(v)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, result...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+// executeJSONPathTemplate renders the given nodes for a document that was
+// decoded with json.Decoder.UseNumber.
+func executeJSONPathTemplate(sb *strings.Builder, nodes []jsonPathNode, root, current any) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case jsonPathText:
+			sb.WriteString(string(n))
+		case jsonPathPrint:
+			values, err := jsonPathExpr(n).evaluate(root, current)
+			if err != nil {
+				return err
+			}
+			for idx, v := range values {
+				if idx > 0 {
+					sb.WriteString(" ")
+				}
+				str, err := formatJSONPathValue(v)
+				if err != nil {
+					return err
+				}
+				sb.WriteString(str)
+			}
+		case *jsonPathRange:
+			values, err := n.expr.evaluate(root, current)
+			if err != nil {
+				return err
+			}
+			for _, v := range values {
+				err := executeJSONPathTemplate(sb, n.body, root, v)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func formatJSONPathValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(buf), nil
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// MaxQuotaChange describes a change of the max_quota constraint of a single
+// project resource. A nil value means that no constraint is set.
+type MaxQuotaChange struct {
+	ServiceType  limes.ServiceType
+	ResourceName limesresources.ResourceName
+	Unit         limes.Unit
+	Before       *uint64
+	After        *uint64
+}
+
+// MaxQuotaChangesReport renders a before/after comparison of the max_quota
+// constraints of a single project.
+type MaxQuotaChangesReport struct {
+	Changes []MaxQuotaChange
+}
+
+var csvHeaderMaxQuotaChanges = []string{
+	csvHeaderService, csvHeaderResource, csvHeaderMaxQuotaBefore, csvHeaderMaxQuotaAfter, csvHeaderUnit,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (m MaxQuotaChangesReport) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderMaxQuotaChanges
+}
+
+// Render implements the LimesReportRenderer interface.
+func (m MaxQuotaChangesReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize changes in a stable order
+	changes := slices.Clone(m.Changes)
+	slices.SortFunc(changes, func(lhs, rhs MaxQuotaChange) int {
+		return cmp.Or(
+			cmp.Compare(lhs.ServiceType, rhs.ServiceType),
+			cmp.Compare(lhs.ResourceName, rhs.ResourceName),
+		)
+	})
+
+	for _, c := range changes {
+		unit, formatter := c.Unit, DefaultValueFormatter
+		if opts.Humanize {
+			unit, formatter = PickHumanizedValueFormatter(unit, []uint64{zeroIfNil(c.Before), zeroIfNil(c.After)})
+		}
+		records = append(records, []string{
+			string(c.ServiceType), string(c.ResourceName),
+			emptyStrIfNil(c.Before, formatter), emptyStrIfNil(c.After, formatter), unit.String(),
+		})
+	}
+
+	return records
+}
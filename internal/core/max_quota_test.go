@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/sapcc/go-api-declarations/limes"
+)
+
+func TestMaxQuotaChangesReportRender(t *testing.T) {
+	p := func(v uint64) *uint64 { return &v }
+	rep := MaxQuotaChangesReport{
+		Changes: []MaxQuotaChange{
+			{ServiceType: "shared", ResourceName: "things", Unit: limes.UnitNone, Before: nil, After: p(200)},
+			{ServiceType: "shared", ResourceName: "capacity", Unit: limes.UnitMebibytes, Before: p(10240), After: p(20480)},
+			{ServiceType: "first", ResourceName: "capacity", Unit: limes.UnitBytes, Before: p(1 << 30), After: nil},
+		},
+	}
+
+	var actual bytes.Buffer
+	err := RenderReports(&OutputOpts{Humanize: true}, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "max-quota-changes-humanize.csv", actual.Bytes())
+}
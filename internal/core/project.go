@@ -4,7 +4,9 @@
 package core
 
 import (
+	"maps"
 	"slices"
+	"strconv"
 
 	"github.com/sapcc/go-api-declarations/limes"
 	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
@@ -46,27 +48,57 @@ var csvHeaderProjectDefault = []string{
 var csvHeaderProjectLong = []string{
 	csvHeaderDomainID, csvHeaderDomainName, csvHeaderProjectID, csvHeaderProjectName,
 	csvHeaderArea, csvHeaderService, csvHeaderCategory, csvHeaderResource,
+	csvHeaderQuota, csvHeaderUsableQuota, csvHeaderMaxQuota, csvHeaderBackendQuota, csvHeaderBackendQuotaDrift,
+	csvHeaderForbidAutogrow, csvHeaderUsage, csvHeaderPhysicalUsage,
+	csvHeaderUnit, csvHeaderScrapedAt,
+}
+
+var csvHeaderProjectPerAZDefault = []string{
+	csvHeaderDomainID, csvHeaderProjectID,
+	csvHeaderService, csvHeaderResource, csvHeaderAZ, csvHeaderQuota, csvHeaderUsage,
+	csvHeaderUnit,
+}
+
+var csvHeaderProjectPerAZLong = []string{
+	csvHeaderDomainID, csvHeaderDomainName, csvHeaderProjectID, csvHeaderProjectName,
+	csvHeaderArea, csvHeaderService, csvHeaderCategory, csvHeaderResource, csvHeaderAZ,
 	csvHeaderQuota, csvHeaderUsage, csvHeaderPhysicalUsage,
 	csvHeaderUnit, csvHeaderScrapedAt,
 }
 
 // GetHeaderRow implements the LimesReportRenderer interface.
 func (p ProjectResourcesReport) getHeaderRow(opts *OutputOpts) []string {
+	if opts.PerAZ {
+		switch opts.CSVRecFmt {
+		case CSVRecordFormatLong:
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderProjectPerAZLong), csvHeaderQuotaUtilization)
+		case CSVRecordFormatNames:
+			h := slices.Clone(csvHeaderProjectPerAZDefault)
+			h[0] = csvHeaderDomainName
+			h[1] = csvHeaderProjectName
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, h), csvHeaderQuotaUtilization)
+		default:
+			return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderProjectPerAZDefault), csvHeaderQuotaUtilization)
+		}
+	}
 	switch opts.CSVRecFmt {
 	case CSVRecordFormatLong:
-		return csvHeaderProjectLong
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderProjectLong), csvHeaderQuotaUtilization)
 	case CSVRecordFormatNames:
 		h := csvHeaderProjectDefault
 		h[0] = csvHeaderDomainName
 		h[1] = csvHeaderProjectName
-		return h
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, h), csvHeaderQuotaUtilization)
 	default:
-		return csvHeaderProjectDefault
+		return appendUtilizationHeaders(opts, appendCommitmentHeaders(opts, csvHeaderProjectDefault), csvHeaderQuotaUtilization)
 	}
 }
 
 // Render implements the LimesReportRenderer interface.
 func (p ProjectResourcesReport) render(opts *OutputOpts) CSVRecords {
+	if opts.PerAZ {
+		return p.renderPerAZ(opts)
+	}
 	var records CSVRecords
 
 	// Serialize service types with ordered keys
@@ -92,18 +124,41 @@ func (p ProjectResourcesReport) render(opts *OutputOpts) CSVRecords {
 
 			physU := pSrvRes.PhysicalUsage
 			quota := pSrvRes.Quota
+			usableQ := pSrvRes.UsableQuota
+			maxQ := pSrvRes.MaxQuota
+			backendQ := pSrvRes.BackendQuota
 			usage := pSrvRes.Usage
 
+			var commitments resourceCommitments
+			for _, azRep := range pSrvRes.PerAZ {
+				commitments.add(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+			}
+
 			unit, formatter := pSrvRes.Unit, DefaultValueFormatter
 			if opts.Humanize {
-				unit, formatter = PickHumanizedValueFormatter(unit, []uint64{
-					zeroIfNil(physU), zeroIfNil(quota), usage,
-				})
+				unit, formatter = PickHumanizedValueFormatter(unit, append([]uint64{
+					zeroIfNil(physU), zeroIfNil(quota), zeroIfNil(usableQ), zeroIfNil(maxQ),
+					uint64(max(zeroIfNilInt(backendQ), 0)), usage, //nolint:gosec // negative values are excluded
+				}, commitments.values()...))
+			}
+
+			quotaStr := emptyStrIfNil(quota, formatter)
+			backendQStr := formatBackendQuota(backendQ, formatter)
+			hasDrift := HasBackendQuotaDrift(pSrvRes)
+			if hasDrift {
+				if opts.CSVRecFmt != CSVRecordFormatLong {
+					// without the backend quota columns, the drift needs to be
+					// visible without colors, too
+					quotaStr += backendQuotaDriftMarker
+				}
+				quotaStr = colorize(opts, colorDrift, quotaStr)
+				backendQStr = colorize(opts, colorDrift, backendQStr)
 			}
 
 			if opts.CSVRecFmt == CSVRecordFormatLong {
 				r = append(r, p.DomainID, p.DomainName, p.UUID, p.Name, pSrv.Area, string(pSrv.Type), pSrvRes.Category,
-					string(pSrvRes.Name), emptyStrIfNil(quota, formatter), formatter(usage),
+					string(pSrvRes.Name), quotaStr, emptyStrIfNil(usableQ, formatter), emptyStrIfNil(maxQ, formatter),
+					backendQStr, strconv.FormatBool(hasDrift), strconv.FormatBool(pSrvRes.ForbidAutogrowth), formatter(usage),
 					emptyStrIfNil(physU, formatter), unit.String(), timestampToString(pSrv.ScrapedAt),
 				)
 			} else {
@@ -114,13 +169,101 @@ func (p ProjectResourcesReport) render(opts *OutputOpts) CSVRecords {
 					domainNameOrID = p.DomainName
 				}
 				r = append(r, domainNameOrID, projectNameOrID, string(pSrv.Type), string(pSrvRes.Name),
-					emptyStrIfNil(quota, formatter), formatter(usage), unit.String(),
+					quotaStr, formatter(usage), unit.String(),
 				)
 			}
 
-			records = append(records, r)
+			r = commitments.appendColumns(opts, r, formatter)
+			records = append(records, appendUtilizationColumns(opts, r, formatUtilization(usage, quota)))
 		}
 	}
 
 	return records
 }
+
+// HasBackendQuotaDrift returns whether the quota that Limes has approved for
+// a resource differs from the quota that is actually set in the backend.
+func HasBackendQuotaDrift(res *limesresources.ProjectResourceReport) bool {
+	if res.BackendQuota == nil {
+		return false
+	}
+	return res.Quota == nil || *res.BackendQuota != int64(*res.Quota) //nolint:gosec // quota values fit into int64
+}
+
+// backendQuotaDriftMarker is appended to quota values with backend quota drift
+// in output formats that do not show the backend quota.
+const backendQuotaDriftMarker = "*"
+
+// RemoveResourcesWithoutDrift removes all resources from the report that do
+// not have backend quota drift, as well as all services that do not have any
+// remaining resources.
+func RemoveResourcesWithoutDrift(report *limesresources.ProjectReport) {
+	for srvType, srv := range report.Services {
+		maps.DeleteFunc(srv.Resources, func(_ limesresources.ResourceName, res *limesresources.ProjectResourceReport) bool {
+			return !HasBackendQuotaDrift(res)
+		})
+		if len(srv.Resources) == 0 {
+			delete(report.Services, srvType)
+		}
+	}
+}
+
+// formatBackendQuota formats a backend quota. Negative values (which denote
+// infinite quota) are shown as-is.
+func formatBackendQuota(value *int64, formatter ValueFormatter) string {
+	switch {
+	case value == nil:
+		return ""
+	case *value < 0:
+		return strconv.FormatInt(*value, 10)
+	default:
+		return formatter(uint64(*value))
+	}
+}
+
+// renderPerAZ renders one record per service, resource and availability zone.
+func (p ProjectResourcesReport) renderPerAZ(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	for _, srv := range slices.Sorted(maps.Keys(p.Services)) {
+		pSrv := p.Services[srv]
+		for _, res := range slices.Sorted(maps.Keys(pSrv.Resources)) {
+			pSrvRes := pSrv.Resources[res]
+
+			// pick the same unit for all AZs of the resource
+			unit, formatter := pSrvRes.Unit, DefaultValueFormatter
+			if opts.Humanize {
+				var values []uint64
+				for _, azRep := range pSrvRes.PerAZ {
+					values = append(values, zeroIfNil(azRep.Quota), azRep.Usage, zeroIfNil(azRep.PhysicalUsage))
+					values = append(values, newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments).values()...)
+				}
+				unit, formatter = PickHumanizedValueFormatter(unit, values)
+			}
+
+			for _, az := range slices.Sorted(maps.Keys(pSrvRes.PerAZ)) {
+				azRep := pSrvRes.PerAZ[az]
+				var r []string
+				if opts.CSVRecFmt == CSVRecordFormatLong {
+					r = append(r, p.DomainID, p.DomainName, p.UUID, p.Name, pSrv.Area, string(pSrv.Type), pSrvRes.Category,
+						string(pSrvRes.Name), string(az), emptyStrIfNil(azRep.Quota, formatter), formatter(azRep.Usage),
+						emptyStrIfNil(azRep.PhysicalUsage, formatter), unit.String(), timestampToString(pSrv.ScrapedAt),
+					)
+				} else {
+					projectNameOrID := p.UUID
+					domainNameOrID := p.DomainID
+					if opts.CSVRecFmt == CSVRecordFormatNames {
+						projectNameOrID = p.Name
+						domainNameOrID = p.DomainName
+					}
+					r = append(r, domainNameOrID, projectNameOrID, string(pSrv.Type), string(pSrvRes.Name), string(az),
+						emptyStrIfNil(azRep.Quota, formatter), formatter(azRep.Usage), unit.String(),
+					)
+				}
+				commitments := newResourceCommitments(azRep.Committed, azRep.PendingCommitments, azRep.PlannedCommitments)
+				r = commitments.appendColumns(opts, r, formatter)
+				records = append(records, appendUtilizationColumns(opts, r, formatUtilization(azRep.Usage, azRep.Quota)))
+			}
+		}
+	}
+	return records
+}
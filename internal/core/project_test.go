@@ -37,6 +37,86 @@ func TestProjectResourcesSingleReportRender(t *testing.T) {
 	assertEquals(t, "project-get-dresden.csv", actual.Bytes())
 }
 
+func TestProjectResourcesDriftReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	// only resources with backend quota drift remain, and the drifted values
+	// are highlighted
+	RemoveResourcesWithoutDrift(&data.Project)
+	var actual bytes.Buffer
+	rep := ProjectResourcesReport{
+		ProjectReport: &data.Project,
+		DomainID:      "uuid-for-germany",
+		DomainName:    "germany",
+	}
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatLong,
+		Humanize:  false,
+		Color:     true,
+	}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-dresden-drift-long.csv", actual.Bytes())
+}
+
+func TestProjectResourcesPerAZReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-per-az.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	var actual bytes.Buffer
+	rep := ProjectResourcesReport{
+		ProjectReport: &data.Project,
+		DomainID:      "uuid-for-germany",
+		DomainName:    "germany",
+	}
+
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  true,
+		PerAZ:     true,
+	}
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-per-az-humanize.csv", actual.Bytes())
+
+	opts.CSVRecFmt = CSVRecordFormatLong
+	opts.Humanize = false
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-per-az-long.csv", actual.Bytes())
+
+	// commitments are summed across all AZs
+	opts.CSVRecFmt = CSVRecordFormatDefault
+	opts.Humanize = true
+	opts.PerAZ = false
+	opts.CommitmentsByDuration = true
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-commitments-humanize.csv", actual.Bytes())
+
+	opts.Humanize = false
+	opts.PerAZ = true
+	opts.Commitments = true
+	opts.CommitmentsByDuration = false
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-per-az-commitments.csv", actual.Bytes())
+}
+
 func TestProjectResourcesMultipleReportsRender(t *testing.T) {
 	type listData struct {
 		Projects []limesresources.ProjectReport `json:"projects"`
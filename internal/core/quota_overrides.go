@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
+)
+
+// QuotaOverrides has the structure of a quota-overrides.json file, as accepted
+// by limesresources.ParseQuotaOverrides: domain name -> project name ->
+// service type -> resource name -> value. Values are numbers for counted
+// resources, and strings with unit (e.g. "10 GiB") for measured resources.
+type QuotaOverrides map[string]map[string]map[limes.ServiceType]map[limesresources.ResourceName]any
+
+// AddProjectReport adds the current quotas of the given project to the
+// overrides. Resources that do not track quota are skipped.
+func (o QuotaOverrides) AddProjectReport(domainName string, report limesresources.ProjectReport) {
+	projectOverrides := make(map[limes.ServiceType]map[limesresources.ResourceName]any)
+	for srvType, srvReport := range report.Services {
+		srvOverrides := make(map[limesresources.ResourceName]any)
+		for resName, resReport := range srvReport.Resources {
+			if resReport.Quota == nil {
+				continue
+			}
+			if resReport.Unit == limes.UnitNone || resReport.Unit == liquid.UnitPiece {
+				srvOverrides[resName] = *resReport.Quota
+			} else {
+				srvOverrides[resName] = FormatHumanizedValue(resReport.Unit, *resReport.Quota)
+			}
+		}
+		if len(srvOverrides) > 0 {
+			projectOverrides[srvType] = srvOverrides
+		}
+	}
+	if len(projectOverrides) == 0 {
+		return
+	}
+
+	if o[domainName] == nil {
+		o[domainName] = make(map[string]map[limes.ServiceType]map[limesresources.ResourceName]any)
+	}
+	o[domainName][report.Name] = projectOverrides
+}
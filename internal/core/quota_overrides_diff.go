@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// QuotaOverrideDiff compares the quota override for a single project resource
+// with its current quota and usage.
+type QuotaOverrideDiff struct {
+	ServiceType  limes.ServiceType           `json:"service_type"`
+	ResourceName limesresources.ResourceName `json:"resource_name"`
+	Unit         limes.Unit                  `json:"unit,omitempty"`
+	Quota        *uint64                     `json:"quota"`
+	Override     uint64                      `json:"override"`
+	Usage        uint64                      `json:"usage"`
+	BelowUsage   bool                        `json:"below_usage"`
+}
+
+// QuotaOverridesDiffReport contains the QuotaOverrideDiff for each resource of
+// a single project that appears in a quota-overrides file.
+type QuotaOverridesDiffReport struct {
+	DomainID    string              `json:"domain_id"`
+	DomainName  string              `json:"domain_name"`
+	ProjectID   string              `json:"project_id"`
+	ProjectName string              `json:"project_name"`
+	Resources   []QuotaOverrideDiff `json:"resources"`
+}
+
+// NewQuotaOverridesDiffReport compares the quota overrides for a project, as
+// returned by limesresources.ParseQuotaOverrides, with the project's report.
+// Overrides for resources that do not appear in the report are skipped.
+func NewQuotaOverridesDiffReport(
+	domainID, domainName string,
+	report *limesresources.ProjectReport,
+	overrides map[limes.ServiceType]map[limesresources.ResourceName]uint64,
+) QuotaOverridesDiffReport {
+
+	result := QuotaOverridesDiffReport{
+		DomainID:    domainID,
+		DomainName:  domainName,
+		ProjectID:   report.UUID,
+		ProjectName: report.Name,
+		Resources:   []QuotaOverrideDiff{},
+	}
+	for _, srvType := range slices.Sorted(maps.Keys(overrides)) {
+		srvReport := report.Services[srvType]
+		if srvReport == nil {
+			continue
+		}
+		for _, resName := range slices.Sorted(maps.Keys(overrides[srvType])) {
+			resReport := srvReport.Resources[resName]
+			if resReport == nil {
+				continue
+			}
+			override := overrides[srvType][resName]
+			result.Resources = append(result.Resources, QuotaOverrideDiff{
+				ServiceType:  srvType,
+				ResourceName: resName,
+				Unit:         resReport.Unit,
+				Quota:        resReport.Quota,
+				Override:     override,
+				Usage:        resReport.Usage,
+				BelowUsage:   override < resReport.Usage,
+			})
+		}
+	}
+	return result
+}
+
+const quotaOverrideWarningBelowUsage = "override is below usage"
+
+var csvHeaderQuotaOverridesDiffDefault = []string{
+	csvHeaderDomainID, csvHeaderProjectID,
+	csvHeaderService, csvHeaderResource, csvHeaderQuota, csvHeaderOverride, csvHeaderUsage,
+	csvHeaderUnit, csvHeaderWarning,
+}
+
+var csvHeaderQuotaOverridesDiffLong = []string{
+	csvHeaderDomainID, csvHeaderDomainName, csvHeaderProjectID, csvHeaderProjectName,
+	csvHeaderService, csvHeaderResource, csvHeaderQuota, csvHeaderOverride, csvHeaderUsage,
+	csvHeaderUnit, csvHeaderWarning,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (r QuotaOverridesDiffReport) getHeaderRow(opts *OutputOpts) []string {
+	switch opts.CSVRecFmt {
+	case CSVRecordFormatLong:
+		return csvHeaderQuotaOverridesDiffLong
+	case CSVRecordFormatNames:
+		h := slices.Clone(csvHeaderQuotaOverridesDiffDefault)
+		h[0] = csvHeaderDomainName
+		h[1] = csvHeaderProjectName
+		return h
+	default:
+		return csvHeaderQuotaOverridesDiffDefault
+	}
+}
+
+// Render implements the LimesReportRenderer interface.
+func (r QuotaOverridesDiffReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	for _, d := range r.Resources {
+		unit, formatter := d.Unit, DefaultValueFormatter
+		if opts.Humanize {
+			unit, formatter = PickHumanizedValueFormatter(unit, []uint64{zeroIfNil(d.Quota), d.Override, d.Usage})
+		}
+		var warning string
+		if d.BelowUsage {
+			warning = quotaOverrideWarningBelowUsage
+		}
+
+		var rec []string
+		switch opts.CSVRecFmt {
+		case CSVRecordFormatLong:
+			rec = append(rec, r.DomainID, r.DomainName, r.ProjectID, r.ProjectName)
+		case CSVRecordFormatNames:
+			rec = append(rec, r.DomainName, r.ProjectName)
+		default:
+			rec = append(rec, r.DomainID, r.ProjectID)
+		}
+		rec = append(rec, string(d.ServiceType), string(d.ResourceName),
+			emptyStrIfNil(d.Quota, formatter), formatter(d.Override), formatter(d.Usage),
+			unit.String(), warning,
+		)
+		records = append(records, rec)
+	}
+	return records
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestQuotaOverridesFromProjectReports(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-list.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Projects []limesresources.ProjectReport `json:"projects"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	// use a quota that can be humanized
+	tenGiB := uint64(10 << 30)
+	data.Projects[0].Services["shared"].Resources["capacity"].Quota = &tenGiB
+
+	overrides := make(QuotaOverrides)
+	for _, rep := range data.Projects {
+		overrides.AddProjectReport("germany", rep)
+	}
+	actual, err := json.MarshalIndent(overrides, "", "  ")
+	th.AssertNoErr(t, err)
+	assertEquals(t, "quota-overrides-generated.json", append(actual, '\n'))
+
+	// the result must be accepted by Limes
+	getUnit := func(serviceType limes.ServiceType, resourceName limesresources.ResourceName) (limes.Unit, error) {
+		for _, rep := range data.Projects {
+			if res := rep.Services[serviceType].Resources[resourceName]; res != nil {
+				return res.Unit, nil
+			}
+		}
+		return limes.UnitNone, fmt.Errorf("%s/%s not found", serviceType, resourceName)
+	}
+	parsed, errs := limesresources.ParseQuotaOverrides(actual, getUnit)
+	th.AssertEquals(t, 0, len(errs))
+	th.AssertEquals(t, tenGiB, parsed["germany"]["berlin"]["shared"]["capacity"])
+}
+
+func TestValidateQuotaOverrides(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	v := QuotaOverridesValidator{
+		Report: &data.Project,
+		CheckDomain: func(domainName string) error {
+			if domainName != "germany" {
+				return errors.New("domain not found")
+			}
+			return nil
+		},
+		CheckProject: func(domainName, projectName string) error {
+			if projectName != "berlin" && projectName != "dresden" {
+				return errors.New("project not found")
+			}
+			return nil
+		},
+		ProjectNames: func(domainName string) []string {
+			return []string{"berlin", "dresden"}
+		},
+	}
+
+	// YAML input
+	buf, err := fixtureBytes("quota-overrides-invalid.yaml")
+	th.AssertNoErr(t, err)
+	findings, err := v.Validate(buf)
+	th.AssertNoErr(t, err)
+	actual, err := json.MarshalIndent(findings, "", "  ")
+	th.AssertNoErr(t, err)
+	assertEquals(t, "quota-overrides-invalid-findings.json", append(actual, '\n'))
+
+	// JSON input has positions, too
+	findings, err = v.Validate([]byte("{\n  \"germany\": {\n    \"dresden\": {\"shared\": {\"things\": \"many\"}}\n  }\n}\n"))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(findings))
+	th.AssertEquals(t, 3, findings[0].Line)
+	th.AssertEquals(t, 38, findings[0].Column)
+
+	// a valid file has no findings, even without name checks
+	findings, err = QuotaOverridesValidator{Report: &data.Project}.Validate([]byte("germany:\n  somewhere:\n    shared:\n      things: 5\n"))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 0, len(findings))
+
+	th.AssertEquals(t, 1, editDistance("things", "thngs"))
+	th.AssertEquals(t, 3, editDistance("", "abc"))
+	th.AssertEquals(t, "", closestMatch("network", []string{"shared", "unshared"}))
+}
+
+func TestQuotaOverridesDiffReport(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	overrides := map[limes.ServiceType]map[limesresources.ResourceName]uint64{
+		"shared": {
+			"capacity": 1 << 30,
+			"things":   1,
+		},
+		"unshared": {"things": 20},
+		"unknown":  {"things": 5},
+	}
+	rep := NewQuotaOverridesDiffReport("uuid-for-germany", "germany", &data.Project, overrides)
+	th.AssertEquals(t, 3, len(rep.Resources))
+
+	opts := &OutputOpts{CSVRecFmt: CSVRecordFormatNames, Humanize: true}
+	actual := &bytes.Buffer{}
+	err = RenderReports(opts, rep).Write(actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "quota-overrides-diff-names-humanize.csv", actual.Bytes())
+}
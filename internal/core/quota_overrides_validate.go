@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// QuotaOverridesFinding is a single problem in a quota-overrides file.
+type QuotaOverridesFinding struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// String implements the fmt.Stringer interface.
+func (f QuotaOverridesFinding) String() string {
+	s := fmt.Sprintf("%d:%d: %s", f.Line, f.Column, f.Message)
+	if f.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", f.Suggestion)
+	}
+	return s
+}
+
+// QuotaOverridesValidator checks a quota-overrides file against an existing
+// Limes instance.
+type QuotaOverridesValidator struct {
+	// Report is the resource report of any project. It is used to find out
+	// which services and resources exist with which units.
+	Report *limesresources.ProjectReport
+	// CheckDomain and CheckProject are optional. If set, they shall return an
+	// error if the given domain or project does not exist.
+	CheckDomain  func(domainName string) error
+	CheckProject func(domainName, projectName string) error
+	// ProjectNames is optional. If set, it shall return the names of all
+	// projects in the given domain. It is used to suggest close matches for
+	// unknown project names.
+	ProjectNames func(domainName string) []string
+}
+
+// Validate parses a quota-overrides file in JSON or YAML format and returns
+// all problems with their positions in the file. An error is only returned if
+// the file cannot be parsed at all.
+func (v QuotaOverridesValidator) Validate(buf []byte) ([]QuotaOverridesFinding, error) {
+	// JSON is a subset of YAML, so the YAML parser can provide positions for both formats
+	var doc yaml.Node
+	err := yaml.Unmarshal(buf, &doc)
+	if err != nil {
+		return nil, util.WrapError(err, "could not parse quota overrides")
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var findings []QuotaOverridesFinding
+	addFinding := func(node *yaml.Node, suggestion, format string, args ...any) {
+		findings = append(findings, QuotaOverridesFinding{
+			Line:       node.Line,
+			Column:     node.Column,
+			Message:    fmt.Sprintf(format, args...),
+			Suggestion: suggestion,
+		})
+	}
+	forEachEntry := func(node *yaml.Node, what string, action func(key, value *yaml.Node)) {
+		if node.Kind != yaml.MappingNode {
+			addFinding(node, "", "expected a mapping of %s", what)
+			return
+		}
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			action(node.Content[idx], node.Content[idx+1])
+		}
+	}
+
+	forEachEntry(doc.Content[0], "domain names", func(domainKey, domainNode *yaml.Node) {
+		domainName := domainKey.Value
+		domainExists := true
+		if v.CheckDomain != nil {
+			err := v.CheckDomain(domainName)
+			if err != nil {
+				addFinding(domainKey, "", "domain %q: %s", domainName, err.Error())
+				domainExists = false
+			}
+		}
+
+		forEachEntry(domainNode, "project names", func(projectKey, projectNode *yaml.Node) {
+			projectName := projectKey.Value
+			if domainExists && v.CheckProject != nil {
+				err := v.CheckProject(domainName, projectName)
+				if err != nil {
+					var suggestion string
+					if v.ProjectNames != nil {
+						suggestion = closestMatch(projectName, v.ProjectNames(domainName))
+					}
+					addFinding(projectKey, suggestion, "project %q: %s", domainName+"/"+projectName, err.Error())
+				}
+			}
+
+			forEachEntry(projectNode, "service types", func(serviceKey, serviceNode *yaml.Node) {
+				srvType := limes.ServiceType(serviceKey.Value)
+				srvReport := v.Report.Services[srvType]
+				if srvReport == nil {
+					suggestion := closestMatch(srvType, slices.Sorted(maps.Keys(v.Report.Services)))
+					addFinding(serviceKey, string(suggestion), "%q is not a valid service", srvType)
+					return
+				}
+
+				forEachEntry(serviceNode, "resource names", func(resourceKey, valueNode *yaml.Node) {
+					resName := limesresources.ResourceName(resourceKey.Value)
+					fullResourceName := fmt.Sprintf("%s/%s", srvType, resName)
+					resReport := srvReport.Resources[resName]
+					if resReport == nil {
+						suggestion := closestMatch(resName, slices.Sorted(maps.Keys(srvReport.Resources)))
+						addFinding(resourceKey, string(suggestion), "%q is not a valid resource", fullResourceName)
+						return
+					}
+					if resReport.Quota == nil {
+						addFinding(resourceKey, "", "%s does not track quota", fullResourceName)
+						return
+					}
+
+					// check the value with the same parser that Limes uses, by feeding it
+					// a file that contains only this value
+					var value any
+					err := valueNode.Decode(&value)
+					if err != nil {
+						addFinding(valueNode, "", "invalid value for %s: %s", fullResourceName, err.Error())
+						return
+					}
+					single := QuotaOverrides{domainName: {projectName: {srvType: {resName: value}}}}
+					singleJSON, err := json.Marshal(single)
+					if err != nil {
+						addFinding(valueNode, "", "invalid value for %s: %s", fullResourceName, err.Error())
+						return
+					}
+					getUnit := func(limes.ServiceType, limesresources.ResourceName) (limes.Unit, error) {
+						return resReport.Unit, nil
+					}
+					_, errs := limesresources.ParseQuotaOverrides(singleJSON, getUnit)
+					for _, err := range errs {
+						addFinding(valueNode, "", "%s", err.Error())
+					}
+				})
+			})
+		})
+	})
+
+	return findings, nil
+}
+
+// closestMatch returns the candidate with the smallest edit distance to the
+// input, or the empty string if no candidate is close enough to be a likely
+// typo.
+func closestMatch[S ~string](input S, candidates []S) S {
+	var (
+		best         S
+		bestDistance = max(len(input)/3, 2) + 1
+	)
+	for _, c := range candidates {
+		if c == input {
+			continue
+		}
+		d := editDistance(string(input), string(c))
+		if d < bestDistance {
+			best = c
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesrates "github.com/sapcc/go-api-declarations/limes/rates"
+)
+
+// RateLimitChange describes a change of the rate limit of a single project
+// rate. A nil window means that no limit is set.
+type RateLimitChange struct {
+	ServiceType   limes.ServiceType
+	RateName      limesrates.RateName
+	Limit         uint64
+	Window        *limesrates.Window
+	NewLimit      uint64
+	NewWindow     limesrates.Window
+	DefaultLimit  uint64
+	DefaultWindow *limesrates.Window
+}
+
+// RateLimitChangesReport renders a before/after comparison of the rate limits
+// of a single project, together with the default rate limits.
+type RateLimitChangesReport struct {
+	Changes []RateLimitChange
+}
+
+var csvHeaderRateLimitChanges = []string{
+	csvHeaderService, csvHeaderRate,
+	csvHeaderLimit, csvHeaderWindow, csvHeaderNewLimit, csvHeaderNewWindow,
+	csvHeaderDefaultLimit, csvHeaderDefaultWindow,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (r RateLimitChangesReport) getHeaderRow(_ *OutputOpts) []string {
+	return csvHeaderRateLimitChanges
+}
+
+// Render implements the LimesReportRenderer interface.
+func (r RateLimitChangesReport) render(_ *OutputOpts) CSVRecords {
+	var records CSVRecords
+
+	// Serialize changes in a stable order
+	changes := slices.Clone(r.Changes)
+	slices.SortFunc(changes, func(lhs, rhs RateLimitChange) int {
+		return cmp.Or(
+			cmp.Compare(lhs.ServiceType, rhs.ServiceType),
+			cmp.Compare(lhs.RateName, rhs.RateName),
+		)
+	})
+
+	formatter := DefaultValueFormatter
+	for _, c := range changes {
+		var limit, window, defaultLimit, defaultWindow string
+		if c.Window != nil {
+			limit, window = formatter(c.Limit), c.Window.String()
+		}
+		if c.DefaultWindow != nil {
+			defaultLimit, defaultWindow = formatter(c.DefaultLimit), c.DefaultWindow.String()
+		}
+		records = append(records, []string{
+			string(c.ServiceType), string(c.RateName),
+			limit, window, formatter(c.NewLimit), c.NewWindow.String(),
+			defaultLimit, defaultWindow,
+		})
+	}
+
+	return records
+}
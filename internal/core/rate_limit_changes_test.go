@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesrates "github.com/sapcc/go-api-declarations/limes/rates"
+)
+
+func TestRateLimitChangesReportRender(t *testing.T) {
+	w := func(s string) *limesrates.Window {
+		window := limesrates.MustParseWindow(s)
+		return &window
+	}
+	rep := RateLimitChangesReport{
+		Changes: []RateLimitChange{
+			{
+				ServiceType: "shared", RateName: "service/shared/objects:create",
+				Limit: 5000, Window: w("1s"), NewLimit: 1000, NewWindow: *w("1m"),
+				DefaultLimit: 5000, DefaultWindow: w("1s"),
+			},
+			{
+				ServiceType: "shared", RateName: "service/shared/objects:delete",
+				Limit: 2, Window: w("1m"), NewLimit: 1, NewWindow: *w("1s"),
+				DefaultLimit: 1, DefaultWindow: w("1s"),
+			},
+			{
+				ServiceType: "first", RateName: "objects:unlimited",
+				NewLimit: 10, NewWindow: *w("1h"),
+			},
+		},
+	}
+
+	var actual bytes.Buffer
+	err := RenderReports(&OutputOpts{}, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "rate-limit-changes.csv", actual.Bytes())
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+var csvHeaderResourceCommitments = []string{
+	csvHeaderCommitted, csvHeaderPendingCommitments, csvHeaderPlannedCommitments,
+}
+
+var csvHeaderResourceCommitmentsByDuration = []string{
+	csvHeaderCommittedByDuration, csvHeaderPendingByDuration, csvHeaderPlannedByDuration,
+}
+
+// appendCommitmentHeaders appends the headers of the commitment columns to a
+// header row of a resource report, if these columns were requested.
+func appendCommitmentHeaders(opts *OutputOpts, h []string) []string {
+	if !opts.Commitments && !opts.CommitmentsByDuration {
+		return h
+	}
+	h = append(slices.Clone(h), csvHeaderResourceCommitments...)
+	if opts.CommitmentsByDuration {
+		h = append(h, csvHeaderResourceCommitmentsByDuration...)
+	}
+	return h
+}
+
+// resourceCommitments contains the commitment amounts of a resource (or of a
+// single AZ of a resource), keyed by commitment duration.
+type resourceCommitments struct {
+	Committed map[string]uint64
+	Pending   map[string]uint64
+	Planned   map[string]uint64
+}
+
+// newResourceCommitments returns the commitment amounts of a single AZ.
+func newResourceCommitments(committed, pending, planned map[string]uint64) resourceCommitments {
+	var c resourceCommitments
+	c.add(committed, pending, planned)
+	return c
+}
+
+// add adds the commitment amounts of a single AZ.
+func (c *resourceCommitments) add(committed, pending, planned map[string]uint64) {
+	c.Committed = addAmounts(c.Committed, committed)
+	c.Pending = addAmounts(c.Pending, pending)
+	c.Planned = addAmounts(c.Planned, planned)
+}
+
+func addAmounts(dst, src map[string]uint64) map[string]uint64 {
+	if dst == nil && len(src) > 0 {
+		dst = make(map[string]uint64, len(src))
+	}
+	for duration, amount := range src {
+		dst[duration] += amount
+	}
+	return dst
+}
+
+// values returns all amounts, for use with PickHumanizedValueFormatter.
+func (c resourceCommitments) values() []uint64 {
+	var result []uint64
+	for _, m := range []map[string]uint64{c.Committed, c.Pending, c.Planned} {
+		result = append(result, sumAmounts(m))
+		result = append(result, slices.Collect(maps.Values(m))...)
+	}
+	return result
+}
+
+// appendColumns appends the commitment columns to a record, if these columns
+// were requested.
+func (c resourceCommitments) appendColumns(opts *OutputOpts, r []string, formatter ValueFormatter) []string {
+	if !opts.Commitments && !opts.CommitmentsByDuration {
+		return r
+	}
+	r = append(r, formatter(sumAmounts(c.Committed)), formatter(sumAmounts(c.Pending)), formatter(sumAmounts(c.Planned)))
+	if opts.CommitmentsByDuration {
+		r = append(r, formatByDuration(c.Committed, formatter), formatByDuration(c.Pending, formatter),
+			formatByDuration(c.Planned, formatter))
+	}
+	return r
+}
+
+func sumAmounts(m map[string]uint64) uint64 {
+	var sum uint64
+	for _, amount := range m {
+		sum += amount
+	}
+	return sum
+}
+
+// formatByDuration renders amounts by duration like "1 year: 10, 3 years: 20",
+// with the shortest duration first.
+func formatByDuration(m map[string]uint64, formatter ValueFormatter) string {
+	durations := slices.SortedFunc(maps.Keys(m), func(lhs, rhs string) int {
+		if c := durationEnd(lhs).Compare(durationEnd(rhs)); c != 0 {
+			return c
+		}
+		return strings.Compare(lhs, rhs)
+	})
+	parts := make([]string, 0, len(durations))
+	for _, duration := range durations {
+		parts = append(parts, duration+": "+formatter(m[duration]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// durationEnd is used for sorting durations. Durations that cannot be parsed
+// are sorted last.
+func durationEnd(duration string) time.Time {
+	start := time.Unix(0, 0).UTC()
+	d, err := limesresources.ParseCommitmentDuration(duration)
+	if err != nil {
+		return start.AddDate(10000, 0, 0)
+	}
+	return d.AddTo(start)
+}
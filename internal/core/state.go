@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// DesiredState is the content of a state file for 'limesctl plan' and
+// 'limesctl apply'. Domains and projects are identified by name or ID.
+type DesiredState struct {
+	Domains map[string]DesiredDomainState `yaml:"domains"`
+}
+
+// DesiredDomainState appears in type DesiredState.
+type DesiredDomainState struct {
+	Projects map[string]DesiredProjectState `yaml:"projects"`
+}
+
+// DesiredProjectState contains the desired settings of the resources of a
+// single project, keyed by service type and resource name. Resources and
+// settings that do not appear are not managed.
+type DesiredProjectState map[limes.ServiceType]map[limesresources.ResourceName]DesiredResourceState
+
+// DesiredResourceState appears in type DesiredProjectState.
+type DesiredResourceState struct {
+	// MaxQuota may have a unit suffix. The special value "none" removes the
+	// max_quota constraint.
+	MaxQuota         *string             `yaml:"max_quota"`
+	ForbidAutogrowth *bool               `yaml:"forbid_autogrowth"`
+	Commitments      []DesiredCommitment `yaml:"commitments"`
+}
+
+// DesiredCommitment is the minimum amount that shall be committed for a
+// resource in a single availability zone. If the existing commitments do not
+// cover this amount, a new commitment with the given duration is planned for
+// the difference. Existing commitments are never deleted. Amount and Duration
+// are parsed like the respective command-line flags.
+type DesiredCommitment struct {
+	AvailabilityZone limes.AvailabilityZone `yaml:"availability_zone"`
+	Amount           string                 `yaml:"amount"`
+	Duration         string                 `yaml:"duration"`
+}
+
+// MaxQuotaNone is the value of DesiredResourceState.MaxQuota that removes the
+// max_quota constraint.
+const MaxQuotaNone = "none"
+
+// ParseDesiredState parses the content of a state file. Unknown fields are
+// rejected to catch typos.
+func ParseDesiredState(buf []byte) (*DesiredState, error) {
+	var state DesiredState
+	dec := yaml.NewDecoder(bytes.NewReader(buf))
+	dec.KnownFields(true)
+	err := dec.Decode(&state)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, util.WrapError(err, "could not parse state file")
+	}
+	return &state, nil
+}
+
+// PlanActionKind is an enum.
+type PlanActionKind int
+
+// Different types of PlanActionKind.
+const (
+	PlanActionUnchanged PlanActionKind = iota
+	PlanActionAdd
+	PlanActionChange
+)
+
+// PlanAction is a single line in the output of WritePlans.
+type PlanAction struct {
+	Kind         PlanActionKind
+	ServiceType  limes.ServiceType
+	ResourceName limesresources.ResourceName
+	Description  string
+}
+
+// ProjectPlan contains the changes that are required to bring a single
+// project into its desired state.
+type ProjectPlan struct {
+	DomainID    string
+	DomainName  string
+	ProjectID   string
+	ProjectName string
+
+	Actions           []PlanAction
+	MaxQuotaChanges   []MaxQuotaChange
+	AutogrowthChanges []AutogrowthChange
+	NewCommitments    []limesresources.CommitmentRequest
+}
+
+// HasChanges returns whether applying the plan would change anything.
+func (p ProjectPlan) HasChanges() bool {
+	return len(p.MaxQuotaChanges) > 0 || len(p.AutogrowthChanges) > 0 || len(p.NewCommitments) > 0
+}
+
+// ComputeProjectPlan compares the desired state of a project with its current
+// resource report and commitments. All problems with the desired state are
+// reported at once.
+func ComputeProjectPlan(desired DesiredProjectState, report *limesresources.ProjectReport, commitments []limesresources.Commitment) (ProjectPlan, error) {
+	var (
+		plan ProjectPlan
+		errs []error
+	)
+
+	// Serialize service types and resource names with ordered keys
+	types := make([]limes.ServiceType, 0, len(desired))
+	for srvType := range desired {
+		types = append(types, srvType)
+	}
+	slices.Sort(types)
+
+	for _, srvType := range types {
+		names := make([]limesresources.ResourceName, 0, len(desired[srvType]))
+		for resName := range desired[srvType] {
+			names = append(names, resName)
+		}
+		slices.Sort(names)
+
+		for _, resName := range names {
+			resDesired := desired[srvType][resName]
+			fullResourceName := fmt.Sprintf("%s/%s", srvType, resName)
+			srvReport := report.Services[srvType]
+			if srvReport == nil {
+				errs = append(errs, fmt.Errorf("%q is not a valid service", srvType))
+				continue
+			}
+			resReport := srvReport.Resources[resName]
+			if resReport == nil {
+				errs = append(errs, fmt.Errorf("%q is not a valid resource", fullResourceName))
+				continue
+			}
+			addAction := func(kind PlanActionKind, format string, args ...any) {
+				plan.Actions = append(plan.Actions, PlanAction{kind, srvType, resName, fmt.Sprintf(format, args...)})
+			}
+			formatMaxQuota := func(v *uint64) string {
+				if v == nil {
+					return MaxQuotaNone
+				}
+				return FormatHumanizedValue(resReport.Unit, *v)
+			}
+
+			// max_quota
+			if resDesired.MaxQuota != nil {
+				if resReport.Quota == nil {
+					errs = append(errs, fmt.Errorf("%s does not track quota", fullResourceName))
+					continue
+				}
+				var after *uint64
+				if *resDesired.MaxQuota != MaxQuotaNone {
+					value, err := ParseValueInUnit(resReport.Unit, *resDesired.MaxQuota)
+					if err != nil {
+						errs = append(errs, util.WrapError(err, "invalid max quota for "+fullResourceName))
+						continue
+					}
+					after = &value
+				}
+				before := resReport.MaxQuota
+				unchanged := (before == nil && after == nil) || (before != nil && after != nil && *before == *after)
+				switch {
+				case unchanged:
+					addAction(PlanActionUnchanged, "max quota = %s", formatMaxQuota(before))
+				case before == nil:
+					addAction(PlanActionAdd, "max quota = %s", formatMaxQuota(after))
+				default:
+					addAction(PlanActionChange, "max quota %s -> %s", formatMaxQuota(before), formatMaxQuota(after))
+				}
+				if !unchanged {
+					plan.MaxQuotaChanges = append(plan.MaxQuotaChanges, MaxQuotaChange{
+						ServiceType:  srvType,
+						ResourceName: resName,
+						Unit:         resReport.Unit,
+						Before:       before,
+						After:        after,
+					})
+				}
+			}
+
+			// forbid_autogrowth
+			if resDesired.ForbidAutogrowth != nil {
+				after := *resDesired.ForbidAutogrowth
+				switch {
+				case resReport.QuotaDistributionModel != limesresources.AutogrowQuotaDistribution:
+					errs = append(errs, fmt.Errorf("%s does not use the %s quota distribution model",
+						fullResourceName, limesresources.AutogrowQuotaDistribution))
+					continue
+				case resReport.ForbidAutogrowth == after:
+					addAction(PlanActionUnchanged, "forbid autogrowth = %t", after)
+				default:
+					addAction(PlanActionChange, "forbid autogrowth %t -> %t", resReport.ForbidAutogrowth, after)
+					plan.AutogrowthChanges = append(plan.AutogrowthChanges, AutogrowthChange{
+						ServiceType:  srvType,
+						ResourceName: resName,
+						Before:       resReport.ForbidAutogrowth,
+						After:        after,
+					})
+				}
+			}
+
+			// commitments
+			var seenAZs []limes.AvailabilityZone
+			for _, target := range resDesired.Commitments {
+				if slices.Contains(seenAZs, target.AvailabilityZone) {
+					errs = append(errs, fmt.Errorf("%s has more than one commitment target for availability zone %q",
+						fullResourceName, target.AvailabilityZone))
+					continue
+				}
+				seenAZs = append(seenAZs, target.AvailabilityZone)
+
+				amount, err := ParseValueInUnit(resReport.Unit, target.Amount)
+				if err != nil {
+					errs = append(errs, util.WrapError(err, fmt.Sprintf("invalid commitment amount for %s in %s", fullResourceName, target.AvailabilityZone)))
+					continue
+				}
+				duration, err := ParseCommitmentDuration(target.Duration)
+				if err != nil {
+					errs = append(errs, util.WrapError(err, fmt.Sprintf("invalid commitment duration for %s in %s", fullResourceName, target.AvailabilityZone)))
+					continue
+				}
+				existing := committedAmount(commitments, srvType, resName, target.AvailabilityZone)
+				if existing >= amount {
+					addAction(PlanActionUnchanged, "commitments in %s = %s (target: %s)", target.AvailabilityZone,
+						FormatHumanizedValue(resReport.Unit, existing), FormatHumanizedValue(resReport.Unit, amount))
+					continue
+				}
+				addAction(PlanActionAdd, "new commitment of %s in %s for %s (currently committed: %s, target: %s)",
+					FormatHumanizedValue(resReport.Unit, amount-existing), target.AvailabilityZone, duration.String(),
+					FormatHumanizedValue(resReport.Unit, existing), FormatHumanizedValue(resReport.Unit, amount))
+				plan.NewCommitments = append(plan.NewCommitments, limesresources.CommitmentRequest{
+					ServiceType:      srvType,
+					ResourceName:     resName,
+					AvailabilityZone: target.AvailabilityZone,
+					Amount:           amount - existing,
+					Duration:         duration,
+				})
+			}
+		}
+	}
+
+	return plan, errors.Join(errs...)
+}
+
+// committedAmount sums up the commitments for a resource in an AZ that are
+// active or will become active.
+func committedAmount(commitments []limesresources.Commitment, srvType limes.ServiceType, resName limesresources.ResourceName, az limes.AvailabilityZone) uint64 {
+	var sum uint64
+	for _, c := range commitments {
+		if c.ServiceType != srvType || c.ResourceName != resName || c.AvailabilityZone != az {
+			continue
+		}
+		switch c.Status {
+		case liquid.CommitmentStatusPlanned, liquid.CommitmentStatusPending,
+			liquid.CommitmentStatusGuaranteed, liquid.CommitmentStatusConfirmed:
+			sum += c.Amount
+		}
+	}
+	return sum
+}
+
+// WritePlans writes the given plans to w in a human-readable form, followed by
+// a summary line.
+func WritePlans(w io.Writer, plans []ProjectPlan) error {
+	var (
+		sb     strings.Builder
+		counts = make(map[PlanActionKind]int)
+	)
+	for _, plan := range plans {
+		fmt.Fprintf(&sb, "project %s/%s:\n", plan.DomainName, plan.ProjectName)
+		if len(plan.Actions) == 0 {
+			sb.WriteString("  (no managed settings)\n")
+		}
+		for _, action := range plan.Actions {
+			symbol := map[PlanActionKind]string{PlanActionUnchanged: "=", PlanActionAdd: "+", PlanActionChange: "~"}[action.Kind]
+			fmt.Fprintf(&sb, "  %s %s/%s: %s\n", symbol, action.ServiceType, action.ResourceName, action.Description)
+			counts[action.Kind]++
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Plan: %d to add, %d to change, %d unchanged.\n",
+		counts[PlanActionAdd], counts[PlanActionChange], counts[PlanActionUnchanged])
+
+	_, err := io.WriteString(w, sb.String())
+	if err != nil {
+		return util.WrapError(err, "could not write plan")
+	}
+	return nil
+}
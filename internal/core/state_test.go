@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
+)
+
+func TestComputeProjectPlan(t *testing.T) {
+	stateBytes, err := fixtureBytes("state.yaml")
+	th.AssertNoErr(t, err)
+	state, err := ParseDesiredState(stateBytes)
+	th.AssertNoErr(t, err)
+
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	// emulate existing constraints
+	maxQuota := func(v uint64) *uint64 { return &v }
+	things := data.Project.Services["shared"].Resources["things"]
+	things.QuotaDistributionModel = limesresources.AutogrowQuotaDistribution
+	things.MaxQuota = maxQuota(15)
+	data.Project.Services["unshared"].Resources["things"].MaxQuota = maxQuota(5)
+	commitments := []limesresources.Commitment{
+		{ServiceType: "shared", ResourceName: "capacity", AvailabilityZone: "az-one", Amount: 4, Status: liquid.CommitmentStatusConfirmed},
+		{ServiceType: "shared", ResourceName: "capacity", AvailabilityZone: "az-one", Amount: 100, Status: liquid.CommitmentStatusExpired},
+	}
+
+	plan, err := ComputeProjectPlan(state.Domains["germany"].Projects["dresden"], &data.Project, commitments)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, plan.HasChanges())
+	th.AssertEquals(t, 2, len(plan.MaxQuotaChanges))
+	th.AssertEquals(t, 1, len(plan.AutogrowthChanges))
+	th.AssertEquals(t, 1, len(plan.NewCommitments))
+	th.AssertEquals(t, uint64(2), plan.NewCommitments[0].Amount)
+
+	plan.DomainName = "germany"
+	plan.ProjectName = "dresden"
+	var actual bytes.Buffer
+	err = WritePlans(&actual, []ProjectPlan{plan})
+	th.AssertNoErr(t, err)
+	assertEquals(t, "state-plan.txt", actual.Bytes())
+
+	// problems with the desired state are reported all at once
+	desired := DesiredProjectState{
+		"shared": {
+			"capacity_portion": {MaxQuota: maxQuotaStr("10")},
+			"capacity":         {ForbidAutogrowth: new(bool)},
+		},
+		"unknown": {"things": {}},
+		"unshared": {
+			"capacity": {Commitments: []DesiredCommitment{{AvailabilityZone: "az-one", Amount: "1 B", Duration: "1 fortnight"}}},
+		},
+	}
+	_, err = ComputeProjectPlan(desired, &data.Project, nil)
+	th.AssertEquals(t, `shared/capacity does not use the autogrow quota distribution model
+shared/capacity_portion does not track quota
+"unknown" is not a valid service
+invalid commitment duration for unshared/capacity in az-one: could not parse CommitmentDuration "1 fortnight": malformed field "1 fortnight"`, err.Error())
+
+	// unknown fields are rejected
+	_, err = ParseDesiredState([]byte("domains:\n  germany:\n    project: {}\n"))
+	th.AssertEquals(t, true, err != nil)
+}
+
+func maxQuotaStr(s string) *string {
+	return &s
+}
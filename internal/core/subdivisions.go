@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+	"github.com/sapcc/go-api-declarations/liquid"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// Subresources and subcapacities are collectively called subdivisions of a
+// resource. Both are rendered with one row per subdivision, and their
+// attributes are flattened into additional columns named like
+// "attributes.os_type".
+const csvHeaderAttributesPrefix = "attributes."
+
+// SubdivisionFilter selects the subdivisions whose value in the given column
+// (e.g. "attributes.os_type" or "name") equals the given value.
+type SubdivisionFilter struct {
+	Column string
+	Value  string
+}
+
+// ParseSubdivisionFilter parses a filter of the form "column=value".
+func ParseSubdivisionFilter(input string) (SubdivisionFilter, error) {
+	column, value, found := strings.Cut(input, "=")
+	if !found || column == "" {
+		return SubdivisionFilter{}, fmt.Errorf("invalid filter %q: expected format column=value, e.g. attributes.os_type=linux", input)
+	}
+	return SubdivisionFilter{Column: column, Value: value}, nil
+}
+
+// subdivision is a single subresource or subcapacity with flattened attributes.
+type subdivision struct {
+	ServiceType  limes.ServiceType
+	ResourceName limesresources.ResourceName
+	AZ           limes.AvailabilityZone
+	Unit         limes.Unit
+	ID           string
+	Name         string
+	Capacity     *uint64 // only for subcapacities
+	Usage        *uint64
+	Attributes   map[string]string
+}
+
+// rawValues returns the unformatted values of all columns that can be used in
+// a SubdivisionFilter.
+func (s subdivision) rawValues() map[string]string {
+	result := map[string]string{
+		csvHeaderService:  string(s.ServiceType),
+		csvHeaderResource: string(s.ResourceName),
+		csvHeaderAZ:       string(s.AZ),
+		csvHeaderID:       s.ID,
+		csvHeaderName:     s.Name,
+		csvHeaderCapacity: emptyStrIfNil(s.Capacity, DefaultValueFormatter),
+		csvHeaderUsage:    emptyStrIfNil(s.Usage, DefaultValueFormatter),
+	}
+	for key, value := range s.Attributes {
+		result[csvHeaderAttributesPrefix+key] = value
+	}
+	return result
+}
+
+func (s subdivision) resourceKey() string {
+	return string(s.ServiceType) + "/" + string(s.ResourceName)
+}
+
+func (s subdivision) matches(filters []SubdivisionFilter) bool {
+	values := s.rawValues()
+	for _, f := range filters {
+		value, exists := values[f.Column]
+		if !exists || value != f.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// subdivisionList contains the subdivisions of all resources in a report, in
+// the order in which they are rendered.
+type subdivisionList []subdivision
+
+// attributeHeaders returns the column headers for all attributes that appear
+// in any subdivision.
+func (l subdivisionList) attributeHeaders() []string {
+	keys := make(map[string]struct{})
+	for _, s := range l {
+		for key := range s.Attributes {
+			keys[csvHeaderAttributesPrefix+key] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// subdivisionFormat is the unit and value formatter for all subdivisions of
+// a resource.
+type subdivisionFormat struct {
+	Unit      limes.Unit
+	Formatter ValueFormatter
+}
+
+// formats picks the unit and value formatter for each resource, such that all
+// subdivisions of a resource are shown in the same unit.
+func (l subdivisionList) formats(opts *OutputOpts) map[string]subdivisionFormat {
+	values := make(map[string][]uint64)
+	result := make(map[string]subdivisionFormat)
+	for _, s := range l {
+		key := s.resourceKey()
+		values[key] = append(values[key], zeroIfNil(s.Capacity), zeroIfNil(s.Usage))
+		result[key] = subdivisionFormat{s.Unit, DefaultValueFormatter}
+	}
+	if opts.Humanize {
+		for key, f := range result {
+			f.Unit, f.Formatter = PickHumanizedValueFormatter(f.Unit, values[key])
+			result[key] = f
+		}
+	}
+	return result
+}
+
+// renderAttributes returns the values for the given attribute columns.
+func (s subdivision) renderAttributes(headers []string) []string {
+	result := make([]string, len(headers))
+	for idx, h := range headers {
+		result[idx] = s.Attributes[strings.TrimPrefix(h, csvHeaderAttributesPrefix)]
+	}
+	return result
+}
+
+// newSubdivision decodes the attributes of a subresource or subcapacity.
+func newSubdivision(srvType limes.ServiceType, resName limesresources.ResourceName, az limes.AvailabilityZone, unit limes.Unit,
+	id, name string, attributes json.RawMessage) (subdivision, error) {
+
+	s := subdivision{
+		ServiceType:  srvType,
+		ResourceName: resName,
+		AZ:           az,
+		Unit:         unit,
+		ID:           id,
+		Name:         name,
+		Attributes:   make(map[string]string),
+	}
+	if len(attributes) == 0 {
+		return s, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(attributes))
+	dec.UseNumber()
+	var parsed any
+	err := dec.Decode(&parsed)
+	if err != nil {
+		return s, util.WrapError(err, fmt.Sprintf("could not parse attributes of %s/%s", srvType, resName))
+	}
+	err = flattenAttributes(s.Attributes, "", parsed)
+	return s, err
+}
+
+// flattenAttributes adds all values from an attributes object to out. Keys of
+// nested objects are joined with dots. Arrays are rendered as JSON.
+func flattenAttributes(out map[string]string, prefix string, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			err := flattenAttributes(out, key, child)
+			if err != nil {
+				return err
+			}
+		}
+	case nil:
+		out[prefix] = ""
+	case string:
+		out[prefix] = v
+	case json.Number:
+		out[prefix] = v.String()
+	case bool:
+		out[prefix] = strconv.FormatBool(v)
+	default:
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return util.WrapError(err, "could not marshal JSON")
+		}
+		out[prefix] = string(buf)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Subresources.
+
+// SubresourcesReport renders the subresources of all resources in a project
+// report.
+type SubresourcesReport struct {
+	DomainID    string
+	DomainName  string
+	ProjectID   string
+	ProjectName string
+
+	subresources subdivisionList
+}
+
+// NewSubresourcesReport decodes the subresources in a project report. The
+// report must have been requested with the "detail" option. Only subresources
+// that match all filters are included.
+func NewSubresourcesReport(domainID, domainName string, report *limesresources.ProjectReport, filters []SubdivisionFilter) (SubresourcesReport, error) {
+	result := SubresourcesReport{
+		DomainID:    domainID,
+		DomainName:  domainName,
+		ProjectID:   report.UUID,
+		ProjectName: report.Name,
+	}
+
+	add := func(srvType limes.ServiceType, res *limesresources.ProjectResourceReport, az limes.AvailabilityZone, buf json.RawMessage) error {
+		if len(buf) == 0 {
+			return nil
+		}
+		var subresources []liquid.Subresource
+		err := json.Unmarshal(buf, &subresources)
+		if err != nil {
+			return util.WrapError(err, fmt.Sprintf("could not parse subresources of %s/%s", srvType, res.Name))
+		}
+		for _, sr := range subresources {
+			s, err := newSubdivision(srvType, res.Name, az, res.Unit, sr.ID, sr.Name, sr.Attributes)
+			if err != nil {
+				return err
+			}
+			s.Usage = sr.Usage.AsPointer()
+			if s.matches(filters) {
+				result.subresources = append(result.subresources, s)
+			}
+		}
+		return nil
+	}
+
+	for _, srvType := range slices.Sorted(maps.Keys(report.Services)) {
+		srv := report.Services[srvType]
+		for _, resName := range slices.Sorted(maps.Keys(srv.Resources)) {
+			res := srv.Resources[resName]
+			err := add(srvType, res, "", res.Subresources)
+			if err != nil {
+				return result, err
+			}
+			// with the v2 API preview, subresources are reported per AZ
+			for _, az := range slices.Sorted(maps.Keys(res.PerAZ)) {
+				err := add(srvType, res, az, res.PerAZ[az].Subresources)
+				if err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+var csvHeaderSubresourcesDefault = []string{
+	csvHeaderDomainID, csvHeaderProjectID,
+	csvHeaderService, csvHeaderResource, csvHeaderAZ, csvHeaderID, csvHeaderName, csvHeaderUsage,
+	csvHeaderUnit,
+}
+
+var csvHeaderSubresourcesLong = []string{
+	csvHeaderDomainID, csvHeaderDomainName, csvHeaderProjectID, csvHeaderProjectName,
+	csvHeaderService, csvHeaderResource, csvHeaderAZ, csvHeaderID, csvHeaderName, csvHeaderUsage,
+	csvHeaderUnit,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (r SubresourcesReport) getHeaderRow(opts *OutputOpts) []string {
+	var h []string
+	switch opts.CSVRecFmt {
+	case CSVRecordFormatLong:
+		h = slices.Clone(csvHeaderSubresourcesLong)
+	case CSVRecordFormatNames:
+		h = slices.Clone(csvHeaderSubresourcesDefault)
+		h[0] = csvHeaderDomainName
+		h[1] = csvHeaderProjectName
+	default:
+		h = slices.Clone(csvHeaderSubresourcesDefault)
+	}
+	return append(h, r.subresources.attributeHeaders()...)
+}
+
+// Render implements the LimesReportRenderer interface.
+func (r SubresourcesReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	attributeHeaders := r.subresources.attributeHeaders()
+	formats := r.subresources.formats(opts)
+	for _, s := range r.subresources {
+		unit, formatter := formats[s.resourceKey()].Unit, formats[s.resourceKey()].Formatter
+
+		var rec []string
+		switch opts.CSVRecFmt {
+		case CSVRecordFormatLong:
+			rec = append(rec, r.DomainID, r.DomainName, r.ProjectID, r.ProjectName)
+		case CSVRecordFormatNames:
+			rec = append(rec, r.DomainName, r.ProjectName)
+		default:
+			rec = append(rec, r.DomainID, r.ProjectID)
+		}
+		rec = append(rec, string(s.ServiceType), string(s.ResourceName), string(s.AZ), s.ID, s.Name,
+			emptyStrIfNil(s.Usage, formatter), unit.String(),
+		)
+		records = append(records, append(rec, s.renderAttributes(attributeHeaders)...))
+	}
+	return records
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Subcapacities.
+
+// SubcapacitiesReport renders the subcapacities of all resources in a
+// cluster report.
+type SubcapacitiesReport struct {
+	ClusterID string
+
+	subcapacities subdivisionList
+}
+
+// NewSubcapacitiesReport decodes the subcapacities in a cluster report. The
+// report must have been requested with the "detail" option. Only
+// subcapacities that match all filters are included.
+func NewSubcapacitiesReport(report *limesresources.ClusterReport, filters []SubdivisionFilter) (SubcapacitiesReport, error) {
+	result := SubcapacitiesReport{ClusterID: report.ID}
+
+	add := func(srvType limes.ServiceType, res *limesresources.ClusterResourceReport, az limes.AvailabilityZone, buf json.RawMessage) error {
+		if len(buf) == 0 {
+			return nil
+		}
+		var subcapacities []liquid.Subcapacity
+		err := json.Unmarshal(buf, &subcapacities)
+		if err != nil {
+			return util.WrapError(err, fmt.Sprintf("could not parse subcapacities of %s/%s", srvType, res.Name))
+		}
+		for _, sc := range subcapacities {
+			s, err := newSubdivision(srvType, res.Name, az, res.Unit, sc.ID, sc.Name, sc.Attributes)
+			if err != nil {
+				return err
+			}
+			s.Capacity = &sc.Capacity
+			s.Usage = sc.Usage.AsPointer()
+			if s.matches(filters) {
+				result.subcapacities = append(result.subcapacities, s)
+			}
+		}
+		return nil
+	}
+
+	for _, srvType := range slices.Sorted(maps.Keys(report.Services)) {
+		srv := report.Services[srvType]
+		for _, resName := range slices.Sorted(maps.Keys(srv.Resources)) {
+			res := srv.Resources[resName]
+			err := add(srvType, res, "", res.Subcapacities)
+			if err != nil {
+				return result, err
+			}
+			// with the v2 API preview, subcapacities are reported per AZ
+			for _, az := range slices.Sorted(maps.Keys(res.PerAZ)) {
+				err := add(srvType, res, az, res.PerAZ[az].Subcapacities)
+				if err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+var csvHeaderSubcapacities = []string{
+	csvHeaderClusterID, csvHeaderService, csvHeaderResource, csvHeaderAZ, csvHeaderID, csvHeaderName,
+	csvHeaderCapacity, csvHeaderUsage, csvHeaderUnit,
+}
+
+// GetHeaderRow implements the LimesReportRenderer interface.
+func (r SubcapacitiesReport) getHeaderRow(opts *OutputOpts) []string {
+	return append(slices.Clone(csvHeaderSubcapacities), r.subcapacities.attributeHeaders()...)
+}
+
+// Render implements the LimesReportRenderer interface.
+func (r SubcapacitiesReport) render(opts *OutputOpts) CSVRecords {
+	var records CSVRecords
+	attributeHeaders := r.subcapacities.attributeHeaders()
+	formats := r.subcapacities.formats(opts)
+	for _, s := range r.subcapacities {
+		unit, formatter := formats[s.resourceKey()].Unit, formats[s.resourceKey()].Formatter
+
+		rec := []string{
+			r.ClusterID, string(s.ServiceType), string(s.ResourceName), string(s.AZ), s.ID, s.Name,
+			emptyStrIfNil(s.Capacity, formatter), emptyStrIfNil(s.Usage, formatter), unit.String(),
+		}
+		records = append(records, append(rec, s.renderAttributes(attributeHeaders)...))
+	}
+	return records
+}
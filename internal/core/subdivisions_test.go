@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+func TestSubresourcesReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("project-get-subresources.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Project limesresources.ProjectReport `json:"project"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	rep, err := NewSubresourcesReport("uuid-for-germany", "germany", &data.Project, nil)
+	th.AssertNoErr(t, err)
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatNames,
+		Humanize:  true,
+	}
+	var actual bytes.Buffer
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-subresources-names-humanize.csv", actual.Bytes())
+
+	// filter by attribute
+	filter, err := ParseSubdivisionFilter("attributes.os_type=linux")
+	th.AssertNoErr(t, err)
+	rep, err = NewSubresourcesReport("uuid-for-germany", "germany", &data.Project, []SubdivisionFilter{filter})
+	th.AssertNoErr(t, err)
+	opts = &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  false,
+	}
+	actual.Reset()
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-get-subresources-linux.csv", actual.Bytes())
+
+	_, err = ParseSubdivisionFilter("os_type")
+	th.AssertErr(t, err)
+}
+
+func TestSubcapacitiesReportRender(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("cluster-get-subcapacities.json")
+	th.AssertNoErr(t, err)
+	var data struct {
+		Cluster limesresources.ClusterReport `json:"cluster"`
+	}
+	err = json.Unmarshal(mockJSONBytes, &data)
+	th.AssertNoErr(t, err)
+
+	rep, err := NewSubcapacitiesReport(&data.Cluster, nil)
+	th.AssertNoErr(t, err)
+	opts := &OutputOpts{
+		CSVRecFmt: CSVRecordFormatDefault,
+		Humanize:  true,
+	}
+	var actual bytes.Buffer
+	err = RenderReports(opts, rep).Write(&actual)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-subcapacities-humanize.csv", actual.Bytes())
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sapcc/go-api-declarations/limes"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// WriteWithTemplate renders a JSON document (e.g. a Limes API response) with
+// the Go template or JSONPath template from the given output format, which
+// must be one of OutputFormatTemplate, OutputFormatTemplateFile or
+// OutputFormatJSONPath together with its argument.
+//
+// Like in kubectl, the template is applied to the whole document, so "$" and
+// "." refer to the top-level object, e.g. {.project.name}.
+func WriteWithTemplate(w io.Writer, format OutputFormat, buf []byte) error {
+	kind, arg := SplitOutputFormat(format)
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var data any
+	err := dec.Decode(&data)
+	if err != nil {
+		return util.WrapError(err, "could not parse JSON")
+	}
+
+	var sb strings.Builder
+	switch kind {
+	case OutputFormatJSONPath:
+		nodes, err := parseJSONPathTemplate(arg)
+		if err != nil {
+			return util.WrapError(err, "could not parse JSONPath template")
+		}
+		err = executeJSONPathTemplate(&sb, nodes, data, data)
+		if err != nil {
+			return util.WrapError(err, "could not execute JSONPath template")
+		}
+		// like kubectl, end the output with a newline
+		sb.WriteString("\n")
+	case OutputFormatTemplate, OutputFormatTemplateFile:
+		text := arg
+		if kind == OutputFormatTemplateFile {
+			fileBytes, err := os.ReadFile(arg)
+			if err != nil {
+				return err
+			}
+			text = string(fileBytes)
+		}
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return util.WrapError(err, "could not parse template")
+		}
+		err = tmpl.Execute(&sb, data)
+		if err != nil {
+			return util.WrapError(err, "could not execute template")
+		}
+	default:
+		return fmt.Errorf("%q is not a template output format", format)
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	if err != nil {
+		return util.WrapError(err, "could not write output")
+	}
+	return nil
+}
+
+// templateFuncs are the helper functions that are available in templates.
+var templateFuncs = template.FuncMap{
+	// {{ humanize .usage .unit }} renders a value in the best human-readable
+	// unit, e.g. "10 GiB"
+	"humanize": func(value, unitName any) (string, error) {
+		v, err := templateArgToUint64(value)
+		if err != nil {
+			return "", err
+		}
+		unit, err := templateArgToUnit(unitName)
+		if err != nil {
+			return "", err
+		}
+		return FormatHumanizedValue(unit, v), nil
+	},
+	// {{ formatTime .created_at }} renders a UNIX timestamp like in the
+	// CSV output, e.g. "2024-01-01T00:00:00Z"
+	"formatTime": func(value any) (string, error) {
+		v, err := templateArgToUint64(value)
+		if err != nil {
+			return "", err
+		}
+		t := limes.UnixEncodedTime{Time: time.Unix(int64(v), 0).UTC()} //nolint:gosec // timestamps fit into int64
+		return timestampToString(&t), nil
+	},
+	// {{ unit .unit }} resolves a unit name; fields that are omitted from the
+	// response resolve to the unit of countable resources
+	"unit": templateArgToUnit,
+}
+
+func templateArgToUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return parseTemplateUint64(v.String())
+	case string:
+		return parseTemplateUint64(v)
+	case uint64:
+		return v, nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("expected a non-negative number, but got %d", v)
+		}
+		return uint64(v), nil
+	case float64:
+		if v < 0 || v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected a non-negative integer, but got %v", v)
+		}
+		return uint64(v), nil
+	default:
+		return 0, fmt.Errorf("expected a number, but got %v", value)
+	}
+}
+
+func parseTemplateUint64(value string) (uint64, error) {
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a non-negative integer, but got %q", value)
+	}
+	return v, nil
+}
+
+func templateArgToUnit(value any) (limes.Unit, error) {
+	switch v := value.(type) {
+	case nil:
+		return limes.UnitNone, nil
+	case limes.Unit:
+		return v, nil
+	case string:
+		var unit limes.Unit
+		err := json.Unmarshal([]byte(strconv.Quote(v)), &unit)
+		return unit, err
+	default:
+		return limes.UnitNone, fmt.Errorf("expected a unit name, but got %v", value)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func renderTemplateForTest(t *testing.T, format string) (string, error) {
+	t.Helper()
+	mockJSONBytes, err := fixtureBytes("project-get-dresden.json")
+	th.AssertNoErr(t, err)
+	var f OutputFormat
+	th.AssertNoErr(t, f.Set(format))
+	var sb strings.Builder
+	err = WriteWithTemplate(&sb, f, mockJSONBytes)
+	return sb.String(), err
+}
+
+func TestWriteWithJSONPathTemplate(t *testing.T) {
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		// fields, wildcards and indexes
+		{`{.project.name}`, "dresden"},
+		{`{$.project.id}`, "uuid-for-dresden"},
+		{`{.project.name}: {.project.services[0].resources[0].name}`, "dresden: capacity"},
+		{`{.project['parent_id']}`, "uuid-for-berlin"},
+		{`{.project.services[*].type}`, "shared unshared"},
+		{`{.project.services[-1].type}`, "unshared"},
+		{`{.project.services[0].resources[2].annotations}`,
+			`{"annotated":true,"text":"this annotation appears on shared/things of project dresden only"}`},
+		{`{.project.missing}`, ""},
+		// slices
+		{`{.project.services[-1].resources[1:].name}`, "capacity_portion things"},
+		{`{.project.services[0].resources[:2].name}`, "capacity capacity_portion"},
+		{`{.project.services[0].resources[-2:].name}`, "capacity_portion things"},
+		{`{.project.services[0].resources[0:0].name}`, ""},
+		{`{.project.services[0].resources[5:].name}`, ""},
+		// unions
+		{`{.project.services[0].resources[0,2].name}`, "capacity things"},
+		{`{.project.services[*].resources[-1, 0].name}`, "things capacity things capacity"},
+		{`{.project.services[0]['type','scraped_at']}`, "shared 44"},
+		// recursive descent
+		{`{..name}`, "dresden capacity capacity_portion things capacity capacity_portion things"},
+		{`{$..scales_with.factor}`, "2"},
+		{`{.project..resource_name}`, "things"},
+		{`{..resources[0].name}`, "capacity capacity"},
+		// filters
+		{`{.project.services[-1].resources[?(@.name != "capacity_portion")].name}`, "capacity things"},
+		{`{$.project.services[?(@.type=='shared')].resources[?(@.usage>=2)].name}`, "capacity things"},
+		{`{.project.services[0].resources[?(@.usage < 2)].name}`, "capacity_portion"},
+		{`{.project.services[0].resources[?(@.backend_quota == 100)].name}`, "capacity"},
+		{`{.project.services[0].resources[?(@.contained_in)].name}`, "capacity_portion"},
+		{`{.project.services[-1].resources[?(@.name!="a==b")].name}`, "capacity capacity_portion things"},
+		{`{.project.services[0].resources[?(@.annotations.annotated==true)].name}`, "things"},
+		{`{.project.services[0].resources[?(@.annotations.annotated == false)].name}`, ""},
+		{`{.project.services[0].resources[?(@.annotations.annotated != false)].name}`, "things"},
+		{`{.project.services[0].resources[?(@.name == 1)].name}`, ""},
+		// iteration and literals
+		{`{range .project.services[*]}{.type}:{range .resources[?(@.quota)]} {.name}{end}{"\n"}{end}`,
+			"shared: capacity things\nunshared: capacity things\n"},
+		{`{.project.name}{"\t"}{.project.id}`, "dresden\tuuid-for-dresden"},
+	}
+
+	for _, tc := range testCases {
+		actual, err := renderTemplateForTest(t, "jsonpath="+tc.template)
+		if err != nil {
+			t.Errorf("template %s: unexpected error: %s", tc.template, err.Error())
+			continue
+		}
+		if actual != tc.expected+"\n" {
+			t.Errorf("template %s: expected %q, but got %q", tc.template, tc.expected+"\n", actual)
+		}
+	}
+}
+
+func TestWriteWithJSONPathTemplateErrors(t *testing.T) {
+	testCases := []string{
+		`{.project.services`,
+		`{range .project.services[*]}`,
+		`{end}`,
+		`{project.name}`,
+		`{.project.services[0}`,
+		`{.project.services[]}`,
+		`{.project.services[a]}`,
+		`{.project.services[1:x]}`,
+		`{.project.services[5]}`,
+		`{..}`,
+		`{.project.services[?(@.type == shared)]}`,
+		`{.project.services[?(@.type == 'shared)]}`,
+		`{.project.services[0,?(@.type)]}`,
+		`{"unterminated}`,
+	}
+	for _, template := range testCases {
+		_, err := renderTemplateForTest(t, "jsonpath="+template)
+		if err == nil {
+			t.Errorf("template %s: expected an error, but got none", template)
+		}
+	}
+}
+
+func TestWriteWithGoTemplate(t *testing.T) {
+	render := func(format string) string {
+		t.Helper()
+		actual, err := renderTemplateForTest(t, format)
+		th.AssertNoErr(t, err)
+		return actual
+	}
+
+	th.AssertEquals(t, "shared/capacity: 2 B of 10 B (B)\nshared/things: 2 of 10 ()\n",
+		render(`template={{range .project.services}}{{$srv := .type}}{{if eq $srv "shared"}}{{range .resources}}{{if .quota}}`+
+			`{{$srv}}/{{.name}}: {{humanize .usage .unit}} of {{humanize .quota .unit}} ({{unit .unit}}){{"\n"}}{{end}}{{end}}{{end}}{{end}}`))
+	th.AssertEquals(t, "1970-01-01T00:00:44Z", render(`template={{formatTime (index .project.services 0).scraped_at}}`))
+	th.AssertEquals(t, "2 KiB", render(`template={{humanize 2048 "B"}}`))
+
+	// errors
+	var f OutputFormat
+	th.AssertEquals(t, true, f.Set("jsonpath=") != nil)
+	th.AssertEquals(t, true, f.Set("json=foo") != nil)
+	for _, format := range []string{
+		`template={{.foo`,
+		`template={{humanize -1 "B"}}`,
+		`template={{humanize 1.5 "B"}}`,
+		`template={{humanize "-1" "B"}}`,
+		`template={{humanize 1 "furlong"}}`,
+		`template={{formatTime "yesterday"}}`,
+	} {
+		_, err := renderTemplateForTest(t, format)
+		th.AssertEquals(t, true, err != nil)
+	}
+}
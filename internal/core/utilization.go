@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// SortByUtilization is the only supported value for OutputOpts.SortBy. It
+// sorts rows by their utilization percentage, highest first.
+const SortByUtilization = "utilization"
+
+// The utilization columns of each report are limited to the totals that the
+// Limes API reports at the respective level: cluster reports have capacity
+// and (except per AZ) domains quota, whereas domain and project reports do
+// not have capacity.
+var (
+	csvHeaderClusterUtilization      = []string{csvHeaderUsageQuotaPercent, csvHeaderUsageCapacityPercent}
+	csvHeaderClusterPerAZUtilization = []string{csvHeaderUsageCapacityPercent}
+	csvHeaderQuotaUtilization        = []string{csvHeaderUsageQuotaPercent}
+)
+
+// utilizationHeaders are the headers of the columns that contain utilization
+// percentages. If a report has several of them, rows are sorted by the first
+// one in this list.
+var utilizationHeaders = []string{
+	csvHeaderUsageCapacityPercent, csvHeaderUsageQuotaPercent,
+}
+
+// appendUtilizationHeaders appends the headers of the utilization columns to
+// a header row of a resource report, if these columns were requested.
+func appendUtilizationHeaders(opts *OutputOpts, h, utilization []string) []string {
+	if !opts.Utilization {
+		return h
+	}
+	return append(slices.Clone(h), utilization...)
+}
+
+// appendUtilizationColumns appends the values from formatUtilization to a
+// record, if these columns were requested.
+func appendUtilizationColumns(opts *OutputOpts, r []string, values ...string) []string {
+	if !opts.Utilization {
+		return r
+	}
+	return append(r, values...)
+}
+
+// formatUtilization renders usage as a percentage of the given total, e.g.
+// "82.5". If there is no total, the empty string is returned.
+func formatUtilization(usage uint64, total *uint64) string {
+	if total == nil || *total == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(usage)*100/float64(*total), 'f', 1, 64)
+}
+
+// parseUtilization parses a value rendered by formatUtilization. Empty values
+// are reported as not ok.
+func parseUtilization(value string) (float64, bool) {
+	v, err := strconv.ParseFloat(value, 64)
+	return v, err == nil
+}
+
+// applyUtilizationOpts sorts the records (excluding the header row) by
+// utilization and highlights utilization values above the thresholds, as
+// requested by opts.
+func applyUtilizationOpts(opts *OutputOpts, recs CSVRecords) {
+	if len(recs) == 0 {
+		return
+	}
+	var columns []int
+	for _, h := range utilizationHeaders {
+		if idx := slices.Index(recs[0], h); idx >= 0 {
+			columns = append(columns, idx)
+		}
+	}
+	if len(columns) == 0 {
+		return
+	}
+
+	if opts.SortBy == SortByUtilization {
+		idx := columns[0]
+		slices.SortStableFunc(recs[1:], func(lhs, rhs []string) int {
+			lhsValue, lhsOK := parseUtilization(lhs[idx])
+			rhsValue, rhsOK := parseUtilization(rhs[idx])
+			if lhsOK != rhsOK {
+				// rows without utilization go last
+				if lhsOK {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Compare(rhsValue, lhsValue)
+		})
+	}
+
+	if opts.WarnAt > 0 || opts.CritAt > 0 {
+		for _, rec := range recs[1:] {
+			for _, idx := range columns {
+				value, ok := parseUtilization(rec[idx])
+				switch {
+				case !ok:
+					continue
+				case opts.CritAt > 0 && value >= opts.CritAt:
+					rec[idx] = colorize(opts, colorCritical, rec[idx])
+				case opts.WarnAt > 0 && value >= opts.WarnAt:
+					rec[idx] = colorize(opts, colorWarning, rec[idx])
+				}
+			}
+		}
+	}
+}
@@ -26,18 +26,69 @@ const (
 	csvHeaderResource = "resource"
 	csvHeaderRate     = "rate"
 
-	csvHeaderCapacity      = "capacity"
-	csvHeaderQuota         = "quota"
-	csvHeaderProjectsQuota = "projects quota"
-	csvHeaderDomainsQuota  = "domains quota"
-	csvHeaderUsage         = "usage"
-	csvHeaderPhysicalUsage = "physical usage"
-	csvHeaderLimit         = "limit"
-	csvHeaderDefaultLimit  = "default limit"
-	csvHeaderWindow        = "window"
-	csvHeaderDefaultWindow = "default window"
-	csvHeaderUnit          = "unit"
-	csvHeaderScrapedAt     = "scraped at (UTC)"
+	csvHeaderCommitmentUUID       = "commitment uuid"
+	csvHeaderID                   = "id"
+	csvHeaderName                 = "name"
+	csvHeaderAZ                   = "availability zone"
+	csvHeaderAmount               = "amount"
+	csvHeaderDuration             = "duration"
+	csvHeaderStatus               = "status"
+	csvHeaderCreatedAt            = "created at (UTC)"
+	csvHeaderCreatorName          = "creator name"
+	csvHeaderConfirmBy            = "confirm by (UTC)"
+	csvHeaderConfirmedAt          = "confirmed at (UTC)"
+	csvHeaderExpiresAt            = "expires at (UTC)"
+	csvHeaderRemaining            = "remaining"
+	csvHeaderTransferStatus       = "transfer status"
+	csvHeaderTransferToken        = "transfer token"
+	csvHeaderCanBeDeleted         = "can be deleted"
+	csvHeaderWasRenewed           = "was renewed"
+	csvHeaderNotifyOnConfirm      = "notify on confirm"
+	csvHeaderTargetService        = "target service"
+	csvHeaderTargetResource       = "target resource"
+	csvHeaderFromAmount           = "from amount"
+	csvHeaderToAmount             = "to amount"
+	csvHeaderExpiryMonth          = "expiry month"
+	csvHeaderCommitments          = "commitments"
+	csvHeaderProjects             = "projects"
+	csvHeaderMaxQuotaBefore       = "max quota (before)"
+	csvHeaderMaxQuotaAfter        = "max quota (after)"
+	csvHeaderDistribution         = "quota distribution"
+	csvHeaderForbidAutogrow       = "forbid autogrowth"
+	csvHeaderForbidAutogrowBefore = "forbid autogrowth (before)"
+	csvHeaderForbidAutogrowAfter  = "forbid autogrowth (after)"
+	csvHeaderUsableQuota          = "usable quota"
+	csvHeaderMaxQuota             = "max quota"
+	csvHeaderBackendQuota         = "backend quota"
+	csvHeaderBackendQuotaDrift    = "backend quota drift"
+	csvHeaderOverride             = "override"
+	csvHeaderWarning              = "warning"
+
+	csvHeaderCapacity             = "capacity"
+	csvHeaderQuota                = "quota"
+	csvHeaderProjectsQuota        = "projects quota"
+	csvHeaderDomainsQuota         = "domains quota"
+	csvHeaderUsage                = "usage"
+	csvHeaderPhysicalUsage        = "physical usage"
+	csvHeaderUsageQuotaPercent    = "usage/quota (%)"
+	csvHeaderUsageCapacityPercent = "usage/capacity (%)"
+	csvHeaderProjectsUsage        = "projects usage"
+	csvHeaderUnusedCommitments    = "unused commitments"
+	csvHeaderUncommittedUsage     = "uncommitted usage"
+	csvHeaderCommitted            = "committed"
+	csvHeaderPendingCommitments   = "pending commitments"
+	csvHeaderPlannedCommitments   = "planned commitments"
+	csvHeaderCommittedByDuration  = "committed by duration"
+	csvHeaderPendingByDuration    = "pending by duration"
+	csvHeaderPlannedByDuration    = "planned by duration"
+	csvHeaderLimit                = "limit"
+	csvHeaderDefaultLimit         = "default limit"
+	csvHeaderWindow               = "window"
+	csvHeaderDefaultWindow        = "default window"
+	csvHeaderNewLimit             = "new limit"
+	csvHeaderNewWindow            = "new window"
+	csvHeaderUnit                 = "unit"
+	csvHeaderScrapedAt            = "scraped at (UTC)"
 )
 
 func timestampToString(timestamp *limes.UnixEncodedTime) string {
@@ -54,6 +105,13 @@ func zeroIfNil(ptr *uint64) uint64 {
 	return *ptr
 }
 
+func zeroIfNilInt(ptr *int64) int64 {
+	if ptr == nil {
+		return 0
+	}
+	return *ptr
+}
+
 func emptyStrIfNil(ptr *uint64, formatter ValueFormatter) string {
 	if ptr == nil {
 		return ""
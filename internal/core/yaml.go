@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"github.com/sapcc/go-api-declarations/limes"
+	"github.com/sapcc/go-api-declarations/liquid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sapcc/limesctl/v3/internal/util"
+)
+
+// humanizableFields are the fields in Limes API responses that contain values
+// in the unit of the enclosing object (or of the nearest ancestor that has a
+// unit). If such a field contains an object, all values within are in that
+// unit, e.g. for the amounts per commitment duration.
+var humanizableFields = []string{
+	"amount", "backend_quota", "capacity", "committed", "domains_quota",
+	"max_quota", "max_usage", "min_usage", "pending_commitments",
+	"physical_usage", "planned_commitments", "projects_quota",
+	"projects_usage", "quota", "raw_capacity", "uncommitted_usage",
+	"unused_commitments", "usable_quota", "usage",
+}
+
+// ConvertJSONToYAML converts a JSON document (e.g. a Limes API response) into
+// a YAML document with the same structure. If humanize is true, values with a
+// unit are rendered as quantities in the best human-readable unit, e.g. "10
+// GiB" instead of 10737418240.
+func ConvertJSONToYAML(buf []byte, humanize bool) ([]byte, error) {
+	// JSON is a subset of YAML, so parsing into a yaml.Node retains the order of keys
+	var doc yaml.Node
+	err := yaml.Unmarshal(buf, &doc)
+	if err != nil {
+		return nil, util.WrapError(err, "could not parse JSON")
+	}
+	prepareYAMLNode(&doc, limes.UnitNone, humanize)
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	err = enc.Encode(&doc)
+	if err == nil {
+		err = enc.Close()
+	}
+	if err != nil {
+		return nil, util.WrapError(err, "could not marshal YAML")
+	}
+	return out.Bytes(), nil
+}
+
+// prepareYAMLNode removes the JSON-style formatting from the node and its
+// children, and humanizes values if requested.
+func prepareYAMLNode(node *yaml.Node, unit limes.Unit, humanize bool) {
+	node.Style = 0
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			prepareYAMLNode(child, unit, humanize)
+		}
+		return
+	}
+
+	// if the object has a unit, it applies to all its fields
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == "unit" {
+			// units that cannot be parsed are not humanized
+			var parsed limes.Unit
+			err := json.Unmarshal([]byte(strconv.Quote(node.Content[idx+1].Value)), &parsed)
+			if err != nil {
+				parsed = limes.UnitNone
+			}
+			unit = parsed
+		}
+	}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		key, value := node.Content[idx], node.Content[idx+1]
+		prepareYAMLNode(key, unit, humanize)
+		prepareYAMLNode(value, unit, humanize)
+		if humanize && slices.Contains(humanizableFields, key.Value) {
+			humanizeYAMLNode(value, unit)
+		}
+	}
+}
+
+// humanizeYAMLNode replaces all integer values in the node and its children by
+// quantities with the given unit.
+func humanizeYAMLNode(node *yaml.Node, unit limes.Unit) {
+	if unit == limes.UnitNone || unit == liquid.UnitPiece {
+		return
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!int" {
+		value, err := strconv.ParseUint(node.Value, 10, 64)
+		if err == nil {
+			node.Tag = "!!str"
+			node.Value = FormatHumanizedValue(unit, value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		humanizeYAMLNode(child, unit)
+	}
+}
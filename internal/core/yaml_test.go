@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestConvertJSONToYAML(t *testing.T) {
+	mockJSONBytes, err := fixtureBytes("cluster-get-west.json")
+	th.AssertNoErr(t, err)
+
+	actual, err := ConvertJSONToYAML(mockJSONBytes, false)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-west.yaml", actual)
+
+	actual, err = ConvertJSONToYAML(mockJSONBytes, true)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "cluster-get-west-humanize.yaml", actual)
+
+	// commitment amounts are humanized, too
+	mockJSONBytes, err = fixtureBytes("project-list-commitments.json")
+	th.AssertNoErr(t, err)
+	actual, err = ConvertJSONToYAML(mockJSONBytes, true)
+	th.AssertNoErr(t, err)
+	assertEquals(t, "project-list-commitments-humanize.yaml", actual)
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package limesapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// CommitmentListResult is the result of an operation that returns several
+// commitments. Call its ExtractCommitments method to interpret it as a slice
+// of commitments.
+type CommitmentListResult struct {
+	gophercloud.Result
+}
+
+// ExtractCommitments interprets a CommitmentListResult as a slice of commitments.
+func (r CommitmentListResult) ExtractCommitments() ([]limesresources.Commitment, error) {
+	var s struct {
+		Commitments []limesresources.Commitment `json:"commitments"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Commitments, err
+}
+
+// CommitmentResult is the result of an operation that returns a single
+// commitment. Call its Extract method to interpret it as a commitment.
+type CommitmentResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a CommitmentResult as a commitment.
+func (r CommitmentResult) Extract() (*limesresources.Commitment, error) {
+	var s struct {
+		Commitment *limesresources.Commitment `json:"commitment"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Commitment, err
+}
+
+// CanConfirmResult is the result of a CanConfirmCommitment operation. Call its
+// Extract method to find out whether the commitment can be confirmed.
+type CanConfirmResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a CanConfirmResult as a boolean.
+func (r CanConfirmResult) Extract() (bool, error) {
+	var s struct {
+		Result bool `json:"result"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Result, err
+}
+
+// ConversionListResult is the result of a ListCommitmentConversions
+// operation. Call its ExtractConversions method to interpret it as a slice of
+// conversion rules.
+type ConversionListResult struct {
+	gophercloud.Result
+}
+
+// ExtractConversions interprets a ConversionListResult as a slice of conversion rules.
+func (r ConversionListResult) ExtractConversions() ([]limesresources.CommitmentConversionRule, error) {
+	var s struct {
+		Conversions []limesresources.CommitmentConversionRule `json:"conversions"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Conversions, err
+}
+
+// ListCommitments enumerates the commitments of a specific project.
+func ListCommitments(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string) (r CommitmentListResult) {
+	url := commitmentsURL(c, domainID, projectID)
+	resp, err := c.Get(ctx, url, &r.Body, nil) //nolint:bodyclose // already closed by gophercloud
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListPublicCommitmentsOpts contains parameters for filtering a ListPublicCommitments request.
+type ListPublicCommitmentsOpts struct {
+	ServiceType  limes.ServiceType           `q:"service"`
+	ResourceName limesresources.ResourceName `q:"resource"`
+}
+
+// ListPublicCommitments enumerates the commitments that other projects have
+// marked for public transfer.
+func ListPublicCommitments(ctx context.Context, c *gophercloud.ServiceClient, opts ListPublicCommitmentsOpts) (r CommitmentListResult) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	url := c.ServiceURL("public-commitments") + q.String()
+	resp, err := c.Get(ctx, url, &r.Body, nil) //nolint:bodyclose // already closed by gophercloud
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateCommitment creates a new commitment in a specific project.
+func CreateCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, req limesresources.CommitmentRequest) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, "new")
+	body := map[string]any{"commitment": req}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusCreated},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CanConfirmCommitment checks whether a new commitment with the given
+// parameters could be confirmed immediately, without actually creating it.
+func CanConfirmCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, req limesresources.CommitmentRequest) (r CanConfirmResult) {
+	url := commitmentsURL(c, domainID, projectID, "can-confirm")
+	body := map[string]any{"commitment": req}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusOK},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteCommitment deletes a commitment.
+func DeleteCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string) (r gophercloud.ErrResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID)
+	resp, err := c.Delete(ctx, url, nil) //nolint:bodyclose // already closed by gophercloud
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// StartTransferOpts contains the parameters for a StartCommitmentTransfer request.
+type StartTransferOpts struct {
+	// Amount may be smaller than the commitment's amount. In this case, Limes
+	// splits the commitment and only marks the new part for transfer.
+	Amount         uint64                                  `json:"amount"`
+	TransferStatus limesresources.CommitmentTransferStatus `json:"transfer_status"`
+}
+
+// StartCommitmentTransfer marks a commitment for transfer to another project.
+// The returned commitment contains the transfer token.
+func StartCommitmentTransfer(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string, opts StartTransferOpts) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID, "start-transfer")
+	body := map[string]any{"commitment": opts}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CancelCommitmentTransfer removes the transfer marking from a commitment.
+func CancelCommitmentTransfer(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID, "cancel-transfer")
+	resp, err := c.Post(ctx, url, nil, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusOK, http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetCommitmentByTransferToken retrieves a commitment that is marked for
+// transfer, using its transfer token.
+func GetCommitmentByTransferToken(ctx context.Context, c *gophercloud.ServiceClient, token string) (r CommitmentResult) {
+	url := c.ServiceURL("commitments", token)
+	resp, err := c.Get(ctx, url, &r.Body, nil) //nolint:bodyclose // already closed by gophercloud
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// TransferCommitment moves a commitment that is marked for transfer into the
+// given target project.
+func TransferCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID, token string) (r CommitmentResult) {
+	url := c.ServiceURL("domains", domainID, "projects", projectID, "transfer-commitment", commitmentUUID)
+	resp, err := c.Post(ctx, url, nil, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		MoreHeaders: map[string]string{"Transfer-Token": token},
+		OkCodes:     []int{http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// RenewCommitment creates a renewal for a commitment. The returned commitment
+// is the new one, which will be confirmed once the original one expires.
+func RenewCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID, "renew")
+	resp, err := c.Post(ctx, url, nil, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusOK, http.StatusCreated, http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListCommitmentConversions enumerates the resources that commitments for the
+// given resource can be converted into.
+func ListCommitmentConversions(ctx context.Context, c *gophercloud.ServiceClient, serviceType limes.ServiceType, resourceName limesresources.ResourceName) (r ConversionListResult) {
+	url := c.ServiceURL("commitment-conversion", string(serviceType), string(resourceName))
+	resp, err := c.Get(ctx, url, &r.Body, nil) //nolint:bodyclose // already closed by gophercloud
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ConvertOpts contains the parameters for a ConvertCommitment request.
+type ConvertOpts struct {
+	TargetService  limes.ServiceType           `json:"target_service"`
+	TargetResource limesresources.ResourceName `json:"target_resource"`
+	SourceAmount   uint64                      `json:"source_amount"`
+	TargetAmount   uint64                      `json:"target_amount"`
+}
+
+// ConvertCommitment converts (a part of) a commitment into a commitment for a
+// different resource. The returned commitment is the converted one.
+func ConvertCommitment(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string, opts ConvertOpts) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID, "convert")
+	body := map[string]any{"commitment": opts}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusOK, http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// MergeCommitments merges several active commitments of the same project,
+// resource and AZ into one. The returned commitment is the merged one.
+func MergeCommitments(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, commitmentUUIDs []string) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, "merge")
+	body := map[string]any{"commitment_uuids": commitmentUUIDs}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusAccepted},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateCommitmentDuration extends the duration of a commitment.
+func UpdateCommitmentDuration(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID, commitmentUUID string, duration limesresources.CommitmentDuration) (r CommitmentResult) {
+	url := commitmentsURL(c, domainID, projectID, commitmentUUID, "update-duration")
+	body := map[string]any{"duration": duration}
+	resp, err := c.Post(ctx, url, body, &r.Body, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusOK},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+func commitmentsURL(c *gophercloud.ServiceClient, domainID, projectID string, parts ...string) string {
+	return c.ServiceURL(append([]string{"domains", domainID, "projects", projectID, "commitments"}, parts...)...)
+}
@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+// Package limesapi contains requests against the Limes API that are not
+// (yet) covered by the gophercloud-sapcc package. Its layout follows the
+// conventions of gophercloud: each request returns a result type whose Extract
+// methods interpret the response body.
+package limesapi
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package limesapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/sapcc/go-api-declarations/limes"
+	limesresources "github.com/sapcc/go-api-declarations/limes/resources"
+)
+
+// MaxQuotaResource contains the max_quota constraint for a single resource. A
+// nil MaxQuota removes the constraint.
+type MaxQuotaResource struct {
+	Name     limesresources.ResourceName `json:"name"`
+	MaxQuota *uint64                     `json:"max_quota"`
+	Unit     limes.Unit                  `json:"unit,omitempty"`
+}
+
+// MaxQuotaService contains the max_quota constraints for the resources of a
+// single service.
+type MaxQuotaService struct {
+	Type      limes.ServiceType  `json:"type"`
+	Resources []MaxQuotaResource `json:"resources"`
+}
+
+// SetMaxQuotaOpts contains the parameters for a SetMaxQuota request.
+type SetMaxQuotaOpts struct {
+	Services []MaxQuotaService `json:"services"`
+}
+
+// SetMaxQuota sets or removes the max_quota constraints of some resources of a
+// specific project. Resources that are not mentioned are not changed.
+func SetMaxQuota(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, opts SetMaxQuotaOpts) (r gophercloud.ErrResult) {
+	url := c.ServiceURL("domains", domainID, "projects", projectID, "max-quota")
+	body := map[string]any{"project": opts}
+	resp, err := c.Put(ctx, url, body, nil, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusAccepted, http.StatusNoContent},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ForbidAutogrowthResource contains the forbid_autogrowth setting for a single resource.
+type ForbidAutogrowthResource struct {
+	Name             limesresources.ResourceName `json:"name"`
+	ForbidAutogrowth bool                        `json:"forbid_autogrowth"`
+}
+
+// ForbidAutogrowthService contains the forbid_autogrowth settings for the
+// resources of a single service.
+type ForbidAutogrowthService struct {
+	Type      limes.ServiceType          `json:"type"`
+	Resources []ForbidAutogrowthResource `json:"resources"`
+}
+
+// SetForbidAutogrowthOpts contains the parameters for a SetForbidAutogrowth request.
+type SetForbidAutogrowthOpts struct {
+	Services []ForbidAutogrowthService `json:"services"`
+}
+
+// SetForbidAutogrowth changes the forbid_autogrowth setting of some resources
+// of a specific project. Resources that are not mentioned are not changed.
+func SetForbidAutogrowth(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, opts SetForbidAutogrowthOpts) (r gophercloud.ErrResult) {
+	url := c.ServiceURL("domains", domainID, "projects", projectID, "forbid-autogrowth")
+	body := map[string]any{"project": opts}
+	resp, err := c.Put(ctx, url, body, nil, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusAccepted, http.StatusNoContent},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
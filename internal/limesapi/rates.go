@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company
+// SPDX-License-Identifier: Apache-2.0
+
+package limesapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud/v2"
+	limesrates "github.com/sapcc/go-api-declarations/limes/rates"
+)
+
+// SetProjectRates changes the rate limits of some rates of a specific project.
+// Rates that are not mentioned are not changed. The client must be a client
+// for the Limes rates API.
+func SetProjectRates(ctx context.Context, c *gophercloud.ServiceClient, domainID, projectID string, req limesrates.RateRequest) (r gophercloud.ErrResult) {
+	url := c.ServiceURL("domains", domainID, "projects", projectID)
+	body := map[string]any{"project": map[string]any{"services": req}}
+	resp, err := c.Put(ctx, url, body, nil, &gophercloud.RequestOpts{ //nolint:bodyclose // already closed by gophercloud
+		OkCodes: []int{http.StatusAccepted, http.StatusNoContent},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}